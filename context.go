@@ -0,0 +1,459 @@
+//go:build ruleguard
+
+package gorules
+
+import (
+	"strings"
+
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+// ContextCancelCause detects cancellation reasons stored next to a cancel
+// func and suggests context.WithCancelCause and context.Cause.
+//
+// The old pattern:
+//
+//	ctx, cancel := context.WithCancel(parent)
+//	go func() {
+//	    if err := watch(); err != nil {
+//	        s.stopErr = err
+//	        cancel()
+//	    }
+//	}()
+//	<-ctx.Done()
+//	return s.stopErr
+//
+// New pattern (Go 1.20+):
+//
+//	ctx, cancel := context.WithCancelCause(parent)
+//	go func() {
+//	    if err := watch(); err != nil {
+//	        cancel(err)
+//	    }
+//	}()
+//	<-ctx.Done()
+//	return context.Cause(ctx)
+//
+// Benefits:
+//   - The reason travels with the context to every goroutine that sees Done
+//   - No extra field, side channel or mutex to keep in sync
+//   - context.WithTimeoutCause and WithDeadlineCause (Go 1.21+) attach a
+//     reason to timeouts too
+//
+// See: https://pkg.go.dev/context#WithCancelCause
+// See: https://pkg.go.dev/context#Cause
+func ContextCancelCause(m dsl.Matcher) {
+	// Side-channel error read after the context is done. $x must be a
+	// variable or field assigned right before a cancel func from the same
+	// function is called; stores next to cancel() are not flagged on their
+	// own, since err = srv.Shutdown(ctx); cancel() is ordinary code.
+	m.Match(
+		`func $_($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $x }`,
+		`func $_($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $_, $x }`,
+		`func ($_ $_) $_($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $x }`,
+		`func ($_ $_) $_($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $_, $x }`,
+		`func($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $x }`,
+		`func($*_) $*_ { $*_; $_, $cancel := $_; $*mid; <-$ctx.Done(); return $_, $x }`,
+	).
+		Where(
+			m["cancel"].Type.Is("context.CancelFunc") && m["ctx"].Type.Is("context.Context") &&
+				m["x"].Type.Implements("error") && (m["x"].Node.Is("Ident") || m["x"].Node.Is("SelectorExpr")) &&
+				m["mid"].Contains(`$x = $_; $*_; $cancel()`),
+		).
+		At(m["ctx"]).
+		Report("$x is returned after <-$ctx.Done(); if it is the cancellation reason, attach it with context.WithCancelCause and return context.Cause($ctx) (Go 1.20+)")
+
+	// ctx.Err() only reports Canceled or DeadlineExceeded
+	m.Match(
+		`<-$ctx.Done(); return $ctx.Err()`,
+		`<-$ctx.Done(); return $_, $ctx.Err()`,
+	).
+		Where(m["ctx"].Type.Is("context.Context")).
+		Report("context.Cause($ctx) returns the cancellation reason when one was set and falls back to $ctx.Err(); prefer it to $ctx.Err() after <-$ctx.Done() (Go 1.20+)")
+}
+
+// ContextAfterFunc detects watcher goroutines that wait on ctx.Done() to
+// run cleanup and suggests context.AfterFunc.
+//
+// The old pattern:
+//
+//	go func() {
+//	    <-ctx.Done()
+//	    conn.Close()
+//	}()
+//
+// New pattern (Go 1.21+):
+//
+//	stop := context.AfterFunc(ctx, func() {
+//	    conn.Close()
+//	})
+//	defer stop()
+//
+// Benefits:
+//   - No goroutine is parked (and leaked) when ctx is never canceled
+//   - stop() deregisters the cleanup once it is no longer needed
+//
+// See: https://pkg.go.dev/context#AfterFunc
+func ContextAfterFunc(m dsl.Matcher) {
+	m.Match(
+		`go func() { <-$ctx.Done(); $x; $*_ }()`,
+		`go func() { select { case <-$ctx.Done(): $x; $*_ } }()`,
+	).
+		Where(m["ctx"].Type.Is("context.Context")).
+		Report("goroutine waits on $ctx.Done() to run cleanup; use stop := context.AfterFunc($ctx, func() { ... }) and call stop() when the cleanup is no longer needed (Go 1.21+)")
+
+	// Watcher with a second case to stop it early
+	m.Match(
+		`go func() { select { case <-$ctx.Done(): $x; $*_; case <-$_: $*_ } }()`,
+		`go func() { select { case <-$_: $*_; case <-$ctx.Done(): $x; $*_ } }()`,
+	).
+		Where(m["ctx"].Type.Is("context.Context")).
+		Report("goroutine waits on $ctx.Done() or a stop channel to run cleanup; use stop := context.AfterFunc($ctx, func() { ... }) and call stop() instead of closing the channel (Go 1.21+)")
+}
+
+// ContextWithoutCancel detects hand-written detached contexts and
+// context.Background() calls in HTTP handlers or in functions that already
+// receive a context, and suggests context.WithoutCancel.
+//
+// The old pattern:
+//
+//	type detachedContext struct{ parent context.Context }
+//
+//	func (detachedContext) Deadline() (time.Time, bool)  { return time.Time{}, false }
+//	func (detachedContext) Done() <-chan struct{}        { return nil }
+//	func (detachedContext) Err() error                   { return nil }
+//	func (d detachedContext) Value(key any) any          { return d.parent.Value(key) }
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    go audit(context.Background(), event) // loses trace IDs
+//	}
+//
+// New pattern (Go 1.21+):
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    go audit(context.WithoutCancel(r.Context()), event)
+//	}
+//
+// Benefits:
+//   - Request-scoped values (trace IDs, auth) survive past the response
+//   - No custom context.Context implementation to maintain
+//
+// Note: a hand-written context is reported at its Value method, which is
+// what shows it wraps a parent; the other methods are in separate
+// declarations and can't be checked together with it.
+//
+// See: https://pkg.go.dev/context#WithoutCancel
+func ContextWithoutCancel(m dsl.Matcher) {
+	// Custom context whose Value delegates to a wrapped parent
+	m.Match(
+		`func ($_ $t) Value($k $_) $_ { return $_.Value($k) }`,
+	).
+		Where(m["t"].Type.Implements("context.Context")).
+		Report("$t forwards Value to a wrapped context; if its Deadline, Done and Err report nothing, use context.WithoutCancel(parent) instead (Go 1.21+)")
+
+	// context.Background() inside an HTTP handler
+	m.Match(
+		`func $_($_ http.ResponseWriter, $r *http.Request) { $*body }`,
+		`func ($_ $_) $_($_ http.ResponseWriter, $r *http.Request) { $*body }`,
+		`func($_ http.ResponseWriter, $r *http.Request) { $*body }`,
+	).
+		Where(m["body"].Contains(`context.Background()`)).
+		Report("context.Background() is used in an HTTP handler; use context.WithoutCancel($r.Context()) to outlive the request and keep its values (Go 1.21+)")
+
+	// context.Background() in a function that already receives a context
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) && m["body"].Contains(`context.Background()`)).
+		Report("context.Background() is used in a function that receives a context.Context; use context.WithoutCancel(ctx) to outlive it and keep its values (Go 1.21+)")
+}
+
+// ContextHTTPRequest detects http.NewRequest in functions that receive a
+// context.Context and suggests http.NewRequestWithContext.
+//
+// The old pattern:
+//
+//	func fetch(ctx context.Context, url string) (*http.Response, error) {
+//	    req, err := http.NewRequest("GET", url, nil)
+//	    ...
+//	}
+//
+// New pattern:
+//
+//	func fetch(ctx context.Context, url string) (*http.Response, error) {
+//	    req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+//	    ...
+//	}
+//
+// Benefits:
+//   - Canceling ctx aborts the request instead of waiting for the client timeout
+//
+// Note: the finding is reported on the function, since the ctx parameter is
+// what makes the call fixable. The same applies to ContextSQL,
+// ContextExecCommand and ContextNetDial.
+//
+// See: https://pkg.go.dev/net/http#NewRequestWithContext
+func ContextHTTPRequest(m dsl.Matcher) {
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) && m["body"].Contains(`http.NewRequest($*_)`)).
+		Report("http.NewRequest is called in a function that receives a context.Context; use http.NewRequestWithContext(ctx, ...) so cancellation propagates")
+}
+
+// ContextSQL detects database/sql calls without a context in functions that
+// receive a context.Context and suggests the *Context variants.
+//
+// The old pattern:
+//
+//	func load(ctx context.Context, db *sql.DB, id int) error {
+//	    row := db.QueryRow("SELECT name FROM users WHERE id = ?", id)
+//	    ...
+//	}
+//
+// New pattern:
+//
+//	func load(ctx context.Context, db *sql.DB, id int) error {
+//	    row := db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = ?", id)
+//	    ...
+//	}
+//
+// Benefits:
+//   - Canceling ctx aborts the query and releases the connection
+//
+// Note: the receiver of a call inside the body has no type information, so
+// the method names are only matched in files importing database/sql.
+//
+// See: https://pkg.go.dev/database/sql#DB.QueryContext
+func ContextSQL(m dsl.Matcher) {
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(
+			m.File().Imports("database/sql") && m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) &&
+				(m["body"].Contains(`$_.Query($_, $*_)`) || m["body"].Contains(`$_.QueryRow($_, $*_)`) ||
+					m["body"].Contains(`$_.Exec($_, $*_)`) || m["body"].Contains(`$_.Prepare($_)`) ||
+					m["body"].Contains(`$_.Begin()`)),
+		).
+		Report("database/sql call without a context in a function that receives a context.Context; use QueryContext, QueryRowContext, ExecContext, PrepareContext or BeginTx with ctx so cancellation propagates")
+}
+
+// ContextExecCommand detects exec.Command in functions that receive a
+// context.Context and suggests exec.CommandContext.
+//
+// The old pattern:
+//
+//	func build(ctx context.Context) error {
+//	    return exec.Command("go", "build", "./...").Run()
+//	}
+//
+// New pattern:
+//
+//	func build(ctx context.Context) error {
+//	    return exec.CommandContext(ctx, "go", "build", "./...").Run()
+//	}
+//
+// Benefits:
+//   - Canceling ctx kills the child process
+//
+// See: https://pkg.go.dev/os/exec#CommandContext
+func ContextExecCommand(m dsl.Matcher) {
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) && m["body"].Contains(`exec.Command($*_)`)).
+		Report("exec.Command is called in a function that receives a context.Context; use exec.CommandContext(ctx, ...) so cancellation kills the process")
+}
+
+// ContextNetDial detects net.Dial and net.Lookup* calls in functions that
+// receive a context.Context and suggests the Dialer/Resolver methods taking
+// one.
+//
+// The old pattern:
+//
+//	func connect(ctx context.Context, addr string) (net.Conn, error) {
+//	    return net.Dial("tcp", addr)
+//	}
+//
+// New pattern:
+//
+//	func connect(ctx context.Context, addr string) (net.Conn, error) {
+//	    var d net.Dialer
+//	    return d.DialContext(ctx, "tcp", addr)
+//	}
+//
+// Benefits:
+//   - Canceling ctx aborts the dial or DNS lookup
+//
+// See: https://pkg.go.dev/net#Dialer.DialContext
+// See: https://pkg.go.dev/net#Resolver.LookupHost
+func ContextNetDial(m dsl.Matcher) {
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) && (m["body"].Contains(`net.Dial($*_)`) || m["body"].Contains(`net.DialTimeout($*_)`))).
+		Report("net.Dial is called in a function that receives a context.Context; use (&net.Dialer{}).DialContext(ctx, ...) so cancellation propagates")
+
+	m.Match(
+		`func $_($*params) $*_ { $*body }`,
+		`func ($_ $_) $_($*params) $*_ { $*body }`,
+		`func ($_) $_($*params) $*_ { $*body }`,
+		`func($*params) $*_ { $*body }`,
+	).
+		Where(
+			m["params"].Text.Matches(`(^\(|,\s*)\w+\s+context\.Context\b`) &&
+				(m["body"].Contains(`net.LookupHost($*_)`) || m["body"].Contains(`net.LookupIP($*_)`) ||
+					m["body"].Contains(`net.LookupAddr($*_)`) || m["body"].Contains(`net.LookupCNAME($*_)`) ||
+					m["body"].Contains(`net.LookupMX($*_)`) || m["body"].Contains(`net.LookupTXT($*_)`)),
+		).
+		Report("net.Lookup* is called in a function that receives a context.Context; use the net.DefaultResolver method (e.g. net.DefaultResolver.LookupHost(ctx, host)) so cancellation propagates")
+}
+
+// ContextValueKey detects context.WithValue and ctx.Value calls keyed by
+// built-in or exported types and suggests an unexported key type.
+//
+// The old pattern:
+//
+//	ctx = context.WithValue(ctx, "userID", id)
+//	id, _ := ctx.Value("userID").(string)
+//
+// New pattern:
+//
+//	type userIDKey struct{}
+//
+//	ctx = context.WithValue(ctx, userIDKey{}, id)
+//	id, _ := ctx.Value(userIDKey{}).(string)
+//
+// Benefits:
+//   - Keys from different packages can never collide
+//   - struct{} keys don't allocate when converted to any
+//
+// See: https://pkg.go.dev/context#WithValue
+func ContextValueKey(m dsl.Matcher) {
+	m.Match(`context.WithValue($_, $key, $_)`).
+		Where(
+			m["key"].Type.Is("string") || m["key"].Type.Is("bool") ||
+				m["key"].Type.Is("int") || m["key"].Type.Is("int8") || m["key"].Type.Is("int16") ||
+				m["key"].Type.Is("int32") || m["key"].Type.Is("int64") ||
+				m["key"].Type.Is("uint") || m["key"].Type.Is("uint8") || m["key"].Type.Is("uint16") ||
+				m["key"].Type.Is("uint32") || m["key"].Type.Is("uint64") ||
+				m["key"].Type.Is("float32") || m["key"].Type.Is("float64"),
+		).
+		Report("context.WithValue key $key has a built-in type and can collide across packages; use an unexported key type such as type ctxKey struct{}")
+
+	m.Match(`$ctx.Value($key)`).
+		Where(
+			m["ctx"].Type.Implements("context.Context") &&
+				(m["key"].Type.Is("string") || m["key"].Type.Is("bool") ||
+					m["key"].Type.Is("int") || m["key"].Type.Is("int8") || m["key"].Type.Is("int16") ||
+					m["key"].Type.Is("int32") || m["key"].Type.Is("int64") ||
+					m["key"].Type.Is("uint") || m["key"].Type.Is("uint8") || m["key"].Type.Is("uint16") ||
+					m["key"].Type.Is("uint32") || m["key"].Type.Is("uint64") ||
+					m["key"].Type.Is("float32") || m["key"].Type.Is("float64")),
+		).
+		Report("$ctx.Value lookup with built-in key $key can collide across packages; use an unexported key type such as type ctxKey struct{}")
+
+	// Exported non-struct key type, e.g. otherpkg.Key("user")
+	m.Match(`context.WithValue($_, $key, $_)`).
+		Where(
+			m["key"].Filter(isExportedNamedType) &&
+				(m["key"].Type.Underlying().Is("string") || m["key"].Type.Underlying().OfKind("integer")),
+		).
+		Report("context.WithValue key $key has an exported named type that any package can construct; define an unexported key type such as type ctxKey struct{}")
+}
+
+// isExportedNamedType reports whether the filtered value has a named type
+// declared in a package (not a builtin) whose name is exported.
+func isExportedNamedType(ctx *dsl.VarFilterContext) bool {
+	// Named types print as "import/path.Name"; builtins have no dot.
+	name := ctx.Type.String()
+	if !strings.Contains(name, ".") {
+		return false
+	}
+	for strings.Contains(name, ".") {
+		name = name[1:]
+	}
+	return strings.Contains("ABCDEFGHIJKLMNOPQRSTUVWXYZ", name[:1])
+}
+
+// SignalNotifyContext detects signal channels that are only used to cancel
+// work or block until shutdown and suggests signal.NotifyContext.
+//
+// The old pattern:
+//
+//	sigCh := make(chan os.Signal, 1)
+//	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+//	go func() {
+//	    <-sigCh
+//	    cancel()
+//	}()
+//
+// New pattern:
+//
+//	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//
+// Benefits:
+//   - No channel or goroutine to manage
+//   - stop() restores default signal handling, so a second Ctrl+C exits
+//
+// See: https://pkg.go.dev/os/signal#NotifyContext
+func SignalNotifyContext(m dsl.Matcher) {
+	m.Match(
+		`signal.Notify($ch, $*sigs); go func() { <-$ch; $*_ }()`,
+		`signal.Notify($ch, $*sigs); go func() { $_ := <-$ch; $*_ }()`,
+		`signal.Notify($ch, $*sigs); go func() { $_ = <-$ch; $*_ }()`,
+	).
+		Report("goroutine waits on $ch to cancel work; use ctx, stop := signal.NotifyContext(ctx, $sigs) and defer stop()")
+
+	// Blocking until a signal arrives in main
+	m.Match(
+		`signal.Notify($ch, $*sigs); <-$ch`,
+		`signal.Notify($ch, $*sigs); $_ := <-$ch`,
+		`signal.Notify($ch, $*sigs); $_ = <-$ch`,
+	).
+		Report("blocking on $ch until shutdown; use ctx, stop := signal.NotifyContext(ctx, $sigs), defer stop() and wait on <-ctx.Done() so the same ctx cancels in-flight work")
+}
+
+// SignalNotifyUnbuffered detects signal.Notify on an unbuffered channel.
+//
+// The signal package does not block when sending, so a signal delivered
+// while the receiver is not ready is dropped:
+//
+//	sigCh := make(chan os.Signal)  // BUG: unbuffered
+//	signal.Notify(sigCh, os.Interrupt)
+//
+// Use a buffered channel (or signal.NotifyContext):
+//
+//	sigCh := make(chan os.Signal, 1)
+//	signal.Notify(sigCh, os.Interrupt)
+//
+// See: https://pkg.go.dev/os/signal#Notify
+func SignalNotifyUnbuffered(m dsl.Matcher) {
+	m.Match(
+		`$ch := make(chan os.Signal); signal.Notify($ch, $*_)`,
+		`$ch = make(chan os.Signal); signal.Notify($ch, $*_)`,
+		`$ch := make(chan os.Signal, 0); signal.Notify($ch, $*_)`,
+		`$ch = make(chan os.Signal, 0); signal.Notify($ch, $*_)`,
+		`var $ch = make(chan os.Signal); signal.Notify($ch, $*_)`,
+	).
+		Report("signal.Notify with unbuffered $ch drops signals sent while nobody is receiving; use make(chan os.Signal, 1)")
+
+	m.Match(`signal.Notify(make(chan os.Signal), $*_)`).
+		Report("signal.Notify with an unbuffered channel drops signals; use make(chan os.Signal, 1)")
+}
@@ -52,66 +52,230 @@ func DeprecatedCipherModes(m dsl.Matcher) {
 //
 // Go 1.24 enforces minimum 1024-bit RSA keys, but 2048 bits is the modern recommendation.
 //
-// Weak pattern:
+// Weak patterns:
 //
 //	key, _ := rsa.GenerateKey(rand.Reader, 1024)
 //
+//	const keyBits = 1536
+//	key, _ := rsa.GenerateKey(rand.Reader, keyBits)
+//
 // Recommended:
 //
 //	key, _ := rsa.GenerateKey(rand.Reader, 2048)  // Minimum recommended
 //	key, _ := rsa.GenerateKey(rand.Reader, 4096)  // For long-term security
 //
+// The key size is evaluated as a constant, so named constants and constant
+// expressions are checked as well as literals. Sizes that are only known at
+// run time are not flagged. To enforce a stricter minimum (e.g. 3072 bits),
+// raise the threshold in the last pattern.
+//
 // See: https://pkg.go.dev/crypto/rsa#GenerateKey
 func WeakRSAKeySize(m dsl.Matcher) {
+	// Flag explicitly small keys (will error in Go 1.24+)
+	m.Match(
+		`rsa.GenerateKey($rand, $bits)`,
+	).
+		Where(m["bits"].Value.Int() < 1024).
+		Report("RSA keys smaller than 1024 bits are rejected in Go 1.24+; use at least 2048 bits")
+
 	// Flag 1024-bit keys (allowed but weak)
 	m.Match(
-		`rsa.GenerateKey($rand, 1024)`,
+		`rsa.GenerateKey($rand, $bits)`,
 	).
+		Where(m["bits"].Value.Int() == 1024).
 		Report("RSA 1024-bit keys are considered weak; use at least 2048 bits for modern security")
 
-	// Flag explicitly small keys (will error in Go 1.24+)
+	// Flag any other statically known size below the minimum
 	m.Match(
-		`rsa.GenerateKey($rand, 512)`,
-		`rsa.GenerateKey($rand, 768)`,
+		`rsa.GenerateKey($rand, $bits)`,
 	).
-		Report("RSA keys smaller than 1024 bits are rejected in Go 1.24+; use at least 2048 bits")
+		Where(m["bits"].Value.Int() < 2048).
+		Report("RSA key size $bits is below 2048 bits and considered weak; use at least 2048 bits for modern security")
 }
 
-// DeprecatedElliptic detects deprecated crypto/elliptic usage and suggests
-// using crypto/ecdh instead.
+// WeakAsymmetricKeys detects DSA and P-224 key generation and suggests
+// stronger algorithms.
+//
+// Weak patterns:
+//
+//	var params dsa.Parameters
+//	dsa.GenerateParameters(&params, rand.Reader, dsa.L1024N160)
+//	key, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+//
+// Recommended:
+//
+//	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+//	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+//
+// crypto/dsa is deprecated: DSA is a legacy algorithm, its parameter sizes top
+// out at 3072 bits, and a single biased nonce leaks the private key. P-224
+// provides only 112-bit security, below the 128-bit level of P-256 and
+// Ed25519.
+//
+// See: https://pkg.go.dev/crypto/dsa
+// See: https://pkg.go.dev/crypto/ed25519
+func WeakAsymmetricKeys(m dsl.Matcher) {
+	m.Match(
+		`dsa.GenerateParameters($params, $rand, $sizes)`,
+	).
+		Report("crypto/dsa is deprecated: DSA is a legacy algorithm and leaks the private key on nonce bias; use ed25519.GenerateKey or ECDSA with P-256 instead")
+
+	m.Match(
+		`dsa.GenerateKey($priv, $rand)`,
+	).
+		Report("crypto/dsa is deprecated: DSA is a legacy algorithm and leaks the private key on nonce bias; use ed25519.GenerateKey or ECDSA with P-256 instead")
+
+	m.Match(
+		`ecdsa.GenerateKey(elliptic.P224(), $rand)`,
+	).
+		Report("P-224 provides only 112-bit security; use elliptic.P256() or ed25519.GenerateKey instead").
+		Suggest("ecdsa.GenerateKey(elliptic.P256(), $rand)")
+}
+
+// DeprecatedElliptic detects deprecated crypto/elliptic and raw-r/s
+// crypto/ecdsa usage and suggests crypto/ecdh or the ASN.1 ECDSA functions.
 //
 // Deprecated pattern:
 //
 //	import "crypto/elliptic"
 //	curve := elliptic.P256()
 //	key, _ := elliptic.GenerateKey(curve, rand.Reader)
+//	x, y := curve.ScalarMult(peerX, peerY, priv)
+//
+//	r, s, err := ecdsa.Sign(rand.Reader, priv, hash)
+//	sig, err := asn1.Marshal(ecdsaSignature{r, s})
 //
 // New pattern (Go 1.21+):
 //
 //	import "crypto/ecdh"
 //	key, _ := ecdh.P256().GenerateKey(rand.Reader)
+//	shared, _ := key.ECDH(peerKey)
+//
+//	sig, err := ecdsa.SignASN1(rand.Reader, priv, hash)
+//
+// Deprecated APIs covered:
+//   - elliptic.GenerateKey, Marshal, Unmarshal
+//   - elliptic.Curve methods: ScalarMult, ScalarBaseMult, Add, Double, IsOnCurve
+//
+// Low-level APIs covered (not deprecated, but rarely what callers want):
+//   - elliptic.MarshalCompressed, UnmarshalCompressed
+//   - ecdsa.Sign and ecdsa.Verify (raw r, s values)
 //
 // Benefits:
 //   - Modern, safer API
 //   - Better encapsulation of key material
 //   - Cleaner interface
+//   - Constant-time implementations with point validation built in
 //
 // See: https://pkg.go.dev/crypto/ecdh
+// See: https://pkg.go.dev/crypto/ecdsa#SignASN1
 func DeprecatedElliptic(m dsl.Matcher) {
 	m.Match(
 		`elliptic.GenerateKey($curve, $rand)`,
 	).
 		Report("elliptic.GenerateKey is deprecated; use crypto/ecdh package instead (Go 1.21+)")
 
+	// ecdsa.PublicKey coordinates have a direct conversion via PublicKey.ECDH
+	m.Match(
+		`elliptic.Marshal($curve, $pub.X, $pub.Y)`,
+	).
+		Where(m["pub"].Type.Is("*ecdsa.PublicKey") || m["pub"].Type.Is("ecdsa.PublicKey")).
+		Report("elliptic.Marshal is deprecated; use $pub.ECDH() and ecdh.PublicKey.Bytes() instead (Go 1.20+)")
+
 	m.Match(
 		`elliptic.Marshal($curve, $x, $y)`,
 	).
 		Report("elliptic.Marshal is deprecated; use crypto/ecdh package instead (Go 1.21+)")
 
+	// Named curves map directly onto ecdh.Curve.NewPublicKey, which also validates the point
+	m.Match(
+		`elliptic.Unmarshal(elliptic.P256(), $data)`,
+	).
+		Report("elliptic.Unmarshal is deprecated; use ecdh.P256().NewPublicKey($data) instead (Go 1.21+)")
+
+	m.Match(
+		`elliptic.Unmarshal(elliptic.P384(), $data)`,
+	).
+		Report("elliptic.Unmarshal is deprecated; use ecdh.P384().NewPublicKey($data) instead (Go 1.21+)")
+
+	m.Match(
+		`elliptic.Unmarshal(elliptic.P521(), $data)`,
+	).
+		Report("elliptic.Unmarshal is deprecated; use ecdh.P521().NewPublicKey($data) instead (Go 1.21+)")
+
 	m.Match(
 		`elliptic.Unmarshal($curve, $data)`,
 	).
 		Report("elliptic.Unmarshal is deprecated; use crypto/ecdh package instead (Go 1.21+)")
+
+	m.Match(
+		`elliptic.MarshalCompressed($curve, $x, $y)`,
+	).
+		Report("elliptic.MarshalCompressed is a low-level API; prefer crypto/ecdh or ecdsa.PublicKey.Bytes")
+
+	m.Match(
+		`elliptic.UnmarshalCompressed($curve, $data)`,
+	).
+		Report("elliptic.UnmarshalCompressed is a low-level API; prefer crypto/ecdh, which only accepts uncompressed points, so decompress with filippo.io/nistec (SetBytes) before ecdh.Curve.NewPublicKey")
+
+	// Low-level Curve methods
+	m.Match(
+		`$curve.ScalarMult($x, $y, $k)`,
+	).
+		Where(m["curve"].Type.Implements("elliptic.Curve")).
+		Report("elliptic.Curve.ScalarMult is deprecated; use ecdh.PrivateKey.ECDH for shared secrets instead (Go 1.21+)")
+
+	m.Match(
+		`$curve.ScalarBaseMult($k)`,
+	).
+		Where(m["curve"].Type.Implements("elliptic.Curve")).
+		Report("elliptic.Curve.ScalarBaseMult is deprecated; use ecdh.PrivateKey.PublicKey() instead (Go 1.21+)")
+
+	m.Match(
+		`$curve.IsOnCurve($x, $y)`,
+	).
+		Where(m["curve"].Type.Implements("elliptic.Curve")).
+		Report("elliptic.Curve.IsOnCurve is deprecated; ecdh.Curve.NewPublicKey validates the point and returns an error instead (Go 1.21+)")
+
+	m.Match(
+		`$curve.Add($x1, $y1, $x2, $y2)`,
+		`$curve.Double($x, $y)`,
+	).
+		Where(m["curve"].Type.Implements("elliptic.Curve")).
+		Report("elliptic.Curve point arithmetic is deprecated and not constant-time; use crypto/ecdh, or filippo.io/nistec for low-level group operations")
+
+	// ecdsa.Sign / ecdsa.Verify with r, s used only for ASN.1 encoding: direct fix
+	m.Match(
+		`$r, $s, $err := ecdsa.Sign($rand, $priv, $hash); if $err != nil { $*body }; $sig, $err := asn1.Marshal($typ{$r, $s})`,
+		`$r, $s, $err := ecdsa.Sign($rand, $priv, $hash); if $err != nil { $*body }; $sig, $err := asn1.Marshal($typ{R: $r, S: $s})`,
+	).
+		Report("ecdsa.Sign is a low-level API; prefer ecdsa.SignASN1($rand, $priv, $hash), which returns the ASN.1 signature directly (Go 1.15+)").
+		Suggest("$sig, $err := ecdsa.SignASN1($rand, $priv, $hash); if $err != nil { $body }")
+
+	m.Match(
+		`if _, $err := asn1.Unmarshal($der, &$sig); $err != nil { $*_ }; return ecdsa.Verify($pub, $hash, $sig.R, $sig.S)`,
+	).
+		Report("ecdsa.Verify is a low-level API; prefer ecdsa.VerifyASN1($pub, $hash, $der), which parses the ASN.1 signature directly (Go 1.15+)").
+		Suggest("return ecdsa.VerifyASN1($pub, $hash, $der)")
+
+	// Other uses; skipped when the autofix sequence above already reports
+	// the same call
+	m.Match(
+		`$r, $s, $err := ecdsa.Sign($*_); $*rest`,
+		`$r, $s, $err = ecdsa.Sign($*_); $*rest`,
+	).
+		Where(!m["rest"].Contains(`asn1.Marshal($_{$r, $s})`) && !m["rest"].Contains(`asn1.Marshal($_{R: $r, S: $s})`)).
+		Report("ecdsa.Sign is a low-level API; prefer ecdsa.SignASN1 (Go 1.15+)")
+
+	m.Match(
+		`return ecdsa.Sign($*_)`,
+	).
+		Report("ecdsa.Sign is a low-level API; prefer ecdsa.SignASN1 (Go 1.15+)")
+
+	m.Match(
+		`ecdsa.Verify($pub, $hash, $r, $s)`,
+	).
+		Report("ecdsa.Verify is a low-level API; prefer ecdsa.VerifyASN1 (Go 1.15+)")
 }
 
 // DeprecatedRSAMultiPrime detects deprecated rsa.GenerateMultiPrimeKey.
@@ -175,3 +339,618 @@ func DeprecatedPKCS1v15(m dsl.Matcher) {
 	).
 		Report("rsa.DecryptPKCS1v15SessionKey is deprecated in Go 1.26: PKCS#1 v1.5 encryption is vulnerable to Bleichenbacher attacks; use OAEP-based encryption instead")
 }
+
+// ConstantTimeCompare detects MACs, hashes, signatures and tokens compared
+// with variable-time functions and suggests hmac.Equal or
+// subtle.ConstantTimeCompare.
+//
+// Vulnerable patterns:
+//
+//	mac := hmac.New(sha256.New, key)
+//	mac.Write(msg)
+//	if bytes.Equal(mac.Sum(nil), expectedMAC) { ... }
+//
+//	if hex.EncodeToString(mac.Sum(nil)) == r.Header.Get("X-Signature") { ... }
+//	if sha256.Sum256(body) == expectedSum { ... }
+//	if strings.EqualFold(token, storedToken) { ... }
+//
+// Recommended:
+//
+//	if hmac.Equal(mac.Sum(nil), expectedMAC) { ... }
+//	if subtle.ConstantTimeCompare([]byte(token), []byte(storedToken)) == 1 { ... }
+//
+// bytes.Equal, == and strings.EqualFold return as soon as the first byte
+// differs. An attacker who can measure response times can recover a valid
+// MAC or token one byte at a time.
+//
+// A second set of patterns catches subtle.ConstantTimeCompare and hmac.Equal
+// used on a hex-encoded value and a raw digest. The lengths never match, so
+// the comparison always fails.
+//
+// Operands are matched by origin (hash.Hash.Sum, sha256.Sum256,
+// hex.EncodeToString of a digest) or by a name ending in mac, sig, signature,
+// token, digest or hmac (expectedMAC, csrfToken, sig).
+//
+// See: https://pkg.go.dev/crypto/hmac#Equal
+// See: https://pkg.go.dev/crypto/subtle#ConstantTimeCompare
+func ConstantTimeCompare(m dsl.Matcher) {
+	// bytes.Equal on the output of hash.Hash.Sum (hmac.New(...).Sum, sha256.New().Sum, ...)
+	m.Match(
+		`bytes.Equal($h.Sum($b), $y)`,
+	).
+		Where(m["h"].Type.Is("hash.Hash")).
+		Report("bytes.Equal on a MAC or hash is not constant-time and leaks timing information; use hmac.Equal instead").
+		Suggest("hmac.Equal($h.Sum($b), $y)")
+
+	m.Match(
+		`bytes.Equal($x, $h.Sum($b))`,
+	).
+		Where(m["h"].Type.Is("hash.Hash")).
+		Report("bytes.Equal on a MAC or hash is not constant-time and leaks timing information; use hmac.Equal instead").
+		Suggest("hmac.Equal($x, $h.Sum($b))")
+
+	// bytes.Equal on variables named like a MAC, signature or token
+	m.Match(
+		`bytes.Equal($x, $y)`,
+	).
+		Where(
+			m["x"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`) ||
+				m["y"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`),
+		).
+		Report("bytes.Equal($x, $y) is not constant-time and leaks timing information for MACs, signatures and tokens; use hmac.Equal instead").
+		Suggest("hmac.Equal($x, $y)")
+
+	// == / != on fixed-size digests returned by sha256.Sum256 and friends
+	m.Match(
+		`sha256.Sum256($_) == $y`, `$y == sha256.Sum256($_)`,
+		`sha256.Sum256($_) != $y`, `$y != sha256.Sum256($_)`,
+		`sha512.Sum512($_) == $y`, `$y == sha512.Sum512($_)`,
+		`sha512.Sum512($_) != $y`, `$y != sha512.Sum512($_)`,
+	).
+		Report("comparing a digest with == or != is not constant-time and leaks timing information; use subtle.ConstantTimeCompare on the slices instead")
+
+	// == / != on hex-encoded digests: hash.Hash.Sum output or a slice of a
+	// digest-sized array such as the result of sha256.Sum256
+	m.Match(
+		`hex.EncodeToString($h.Sum($_)) == $y`, `$y == hex.EncodeToString($h.Sum($_))`,
+		`hex.EncodeToString($h.Sum($_)) != $y`, `$y != hex.EncodeToString($h.Sum($_))`,
+	).
+		Where(m["h"].Type.Implements("hash.Hash") && !m["y"].Const).
+		Report("comparing a hex-encoded digest with == or != is not constant-time and leaks timing information; decode it and use hmac.Equal instead")
+
+	m.Match(
+		`hex.EncodeToString($d[:]) == $y`, `$y == hex.EncodeToString($d[:])`,
+		`hex.EncodeToString($d[:]) != $y`, `$y != hex.EncodeToString($d[:])`,
+	).
+		Where((m["d"].Type.Is("[32]byte") || m["d"].Type.Is("[48]byte") || m["d"].Type.Is("[64]byte")) && !m["y"].Const).
+		Report("comparing a hex-encoded digest with == or != is not constant-time and leaks timing information; decode it and use hmac.Equal instead")
+
+	// == / != on strings named like a MAC, signature or token
+	m.Match(
+		`$x == $y`,
+		`$x != $y`,
+	).
+		Where(
+			m["x"].Type.Is("string") && m["y"].Type.Is("string") &&
+				!m["x"].Const && !m["y"].Const &&
+				(m["x"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`) ||
+					m["y"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`)),
+		).
+		Report("comparing $x and $y with == or != is not constant-time and leaks timing information for MACs, signatures and tokens; use subtle.ConstantTimeCompare instead")
+
+	// strings.EqualFold on hex-encoded digests or secrets
+	m.Match(
+		`strings.EqualFold(hex.EncodeToString($_), $y)`,
+		`strings.EqualFold($y, hex.EncodeToString($_))`,
+	).
+		Report("strings.EqualFold on a hex-encoded digest is not constant-time and leaks timing information; decode it and use hmac.Equal instead")
+
+	m.Match(
+		`strings.EqualFold($x, $y)`,
+	).
+		Where(
+			m["x"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`) ||
+				m["y"].Text.Matches(`(^|[._])(mac|hmac|sig|signature|token|digest)s?(\[:\])?$|[a-z0-9](Mac|MAC|Hmac|HMAC|Sig|Signature|Token|Digest)s?(\[:\])?$`),
+		).
+		Report("strings.EqualFold($x, $y) is not constant-time and leaks timing information for MACs, signatures and tokens; use subtle.ConstantTimeCompare instead")
+
+	// Constant-time comparison of a hex string against a raw digest: lengths never match
+	m.Match(
+		`subtle.ConstantTimeCompare([]byte(hex.EncodeToString($_)), $h.Sum($_))`,
+		`subtle.ConstantTimeCompare($h.Sum($_), []byte(hex.EncodeToString($_)))`,
+		`hmac.Equal([]byte(hex.EncodeToString($_)), $h.Sum($_))`,
+		`hmac.Equal($h.Sum($_), []byte(hex.EncodeToString($_)))`,
+	).
+		Where(m["h"].Type.Is("hash.Hash")).
+		Report("comparing a hex-encoded value with a raw digest always fails because the lengths differ; hex.DecodeString the expected value before comparing")
+}
+
+// AEADNonceMisuse detects AEAD Seal calls whose nonce is constant, zero-filled,
+// shared at package level, the wrong size, or reused across loop iterations.
+//
+// Broken patterns:
+//
+//	aead.Seal(nil, []byte("fixednonce12"), plaintext, nil)  // constant nonce
+//	aead.Seal(nil, make([]byte, 12), plaintext, nil)        // all-zero nonce
+//	aead.Seal(nil, globalNonce, plaintext, nil)             // package-level nonce
+//
+//	nonce := make([]byte, aead.NonceSize())
+//	return aead.Seal(nil, nonce, plaintext, nil)  // never filled: all zeros
+//
+//	io.ReadFull(rand.Reader, nonce)
+//	for _, msg := range msgs {
+//	    out = append(out, aead.Seal(nil, nonce, msg, nil))  // same nonce every iteration
+//	}
+//
+// Correct pattern:
+//
+//	nonce := make([]byte, aead.NonceSize())
+//	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+//	    return nil, err
+//	}
+//	return aead.Seal(nonce, nonce, plaintext, nil)
+//
+// Reusing a (key, nonce) pair with GCM reveals the XOR of the plaintexts and
+// lets an attacker recover the authentication key and forge messages.
+//
+// Note: The wrong-size check only looks at variables named nonce and treats 12
+// (GCM, ChaCha20-Poly1305) and 24 (XChaCha20-Poly1305) as valid sizes.
+//
+// See: https://pkg.go.dev/crypto/cipher#AEAD
+func AEADNonceMisuse(m dsl.Matcher) {
+	// Constant nonce passed inline
+	m.Match(
+		`$aead.Seal($_, []byte($s), $_, $_)`,
+		`$aead.Seal($_, []byte{$*_}, $_, $_)`,
+	).
+		Where(m["aead"].Type.Implements("cipher.AEAD")).
+		Report("constant nonce passed to $aead.Seal: reusing a nonce with the same key breaks AEAD confidentiality and authenticity; generate a random nonce per message")
+
+	// Zero-filled nonce passed inline
+	m.Match(
+		`$aead.Seal($_, make([]byte, $_), $_, $_)`,
+	).
+		Where(m["aead"].Type.Implements("cipher.AEAD")).
+		Report("zero-filled nonce passed to $aead.Seal: reusing a nonce with the same key breaks AEAD confidentiality and authenticity; generate a random nonce per message")
+
+	// Package-level nonce shared by every caller
+	m.Match(
+		`$aead.Seal($_, $nonce, $_, $_)`,
+	).
+		Where(m["aead"].Type.Implements("cipher.AEAD") && m["nonce"].Object.IsGlobal()).
+		Report("package-level nonce $nonce passed to $aead.Seal is reused by every call; generate a random nonce per message")
+
+	// Nonce allocated and sealed with before it is filled
+	m.Match(
+		`$nonce := make([]byte, $_); return $aead.Seal($_, $nonce, $_, $_)`,
+		`$nonce := make([]byte, $_); $x := $aead.Seal($_, $nonce, $_, $_)`,
+		`$nonce := make([]byte, $_); $x = $aead.Seal($_, $nonce, $_, $_)`,
+	).
+		Where(m["aead"].Type.Implements("cipher.AEAD")).
+		Report("$nonce is never filled before $aead.Seal and is all zeros; fill it with io.ReadFull(rand.Reader, $nonce) or use cipher.NewGCMWithRandomNonce (Go 1.24+)")
+
+	// Nonce reused across loop iterations
+	m.Match(
+		`for $_, $_ := range $_ { $*pre; $x := $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_, $_ := range $_ { $*pre; $x = $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_, $_ := range $_ { $*pre; $x = append($x, $aead.Seal($_, $nonce, $_, $_)); $*_ }`,
+		`for $_ := range $_ { $*pre; $x := $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_ := range $_ { $*pre; $x = $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_ := range $_ { $*pre; $x = append($x, $aead.Seal($_, $nonce, $_, $_)); $*_ }`,
+		`for $_; $_; $_ { $*pre; $x := $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_; $_; $_ { $*pre; $x = $aead.Seal($_, $nonce, $_, $_); $*_ }`,
+		`for $_; $_; $_ { $*pre; $x = append($x, $aead.Seal($_, $nonce, $_, $_)); $*_ }`,
+	).
+		Where(
+			m["aead"].Type.Implements("cipher.AEAD") &&
+				!m["nonce"].Text.Matches(`\[`) &&
+				!m["pre"].Text.Matches(`ReadFull|rand\.Read|Put(Uint|Varint)|NonceSize`) &&
+				!m["pre"].Contains(`$nonce = $_`) && !m["pre"].Contains(`$nonce := $_`),
+		).
+		Report("$nonce is reused across loop iterations in $aead.Seal; generate a fresh nonce per message or use cipher.NewGCMWithRandomNonce (Go 1.24+)")
+
+	// Nonce buffer of a non-standard size that later reaches Seal or Open.
+	// Only the first make([]byte, ...) of a block is considered.
+	m.Match(
+		`$nonce := make([]byte, $n); $*rest`,
+		`var $nonce = make([]byte, $n); $*rest`,
+	).
+		Where(
+			m["n"].Value.Int() != 12 && m["n"].Value.Int() != 24 &&
+				(m["rest"].Contains(`$_.Seal($_, $nonce, $_, $_)`) || m["rest"].Contains(`$_.Open($_, $nonce, $_, $_)`)),
+		).
+		Report("$nonce is $n bytes but GCM and ChaCha20-Poly1305 expect 12-byte nonces; size it with aead.NonceSize() instead of a hard-coded value")
+}
+
+// GCMWithRandomNonce detects hand-rolled random nonce generation before an
+// AEAD Seal and suggests cipher.NewGCMWithRandomNonce.
+//
+// Old pattern:
+//
+//	aead, _ := cipher.NewGCM(block)
+//	nonce := make([]byte, aead.NonceSize())
+//	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+//	    return nil, err
+//	}
+//	return aead.Seal(nonce, nonce, plaintext, nil)
+//
+// New pattern (Go 1.24+):
+//
+//	aead, _ := cipher.NewGCMWithRandomNonce(block)
+//	return aead.Seal(nil, nil, plaintext, nil)
+//
+// Benefits:
+//   - Nonce generation and prepending are handled by the AEAD
+//   - No way to forget the random fill or reuse a nonce
+//   - Open splits the nonce off the ciphertext automatically
+//
+// See: https://pkg.go.dev/crypto/cipher#NewGCMWithRandomNonce
+func GCMWithRandomNonce(m dsl.Matcher) {
+	m.Match(
+		`$nonce := make([]byte, $aead.NonceSize()); if _, $err := io.ReadFull(rand.Reader, $nonce); $err != nil { $*_ }`,
+		`$nonce := make([]byte, $aead.NonceSize()); if _, $err := rand.Read($nonce); $err != nil { $*_ }`,
+		`$nonce := make([]byte, $aead.NonceSize()); $_, $err = io.ReadFull(rand.Reader, $nonce)`,
+		`$nonce := make([]byte, $aead.NonceSize()); $_, $err := io.ReadFull(rand.Reader, $nonce)`,
+		`$nonce := make([]byte, $aead.NonceSize()); rand.Read($nonce)`,
+	).
+		Where(m["aead"].Type.Implements("cipher.AEAD")).
+		Report("random nonce for $aead is generated by hand; for AES-GCM, use cipher.NewGCMWithRandomNonce (Go 1.24+) which generates and prepends the nonce in Seal")
+}
+
+// WeakHashSecurity detects MD5 and SHA-1 used for HMAC keys, signatures,
+// password checks and tokens.
+//
+// Weak patterns:
+//
+//	mac := hmac.New(md5.New, key)
+//	mac := hmac.New(sha256.New, md5.New().Sum(secret))
+//	sig, _ := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA1, digest)
+//	token := fmt.Sprintf("%x", md5.Sum(seed))
+//	stored := sha1.Sum([]byte(password))
+//
+// Recommended:
+//
+//	mac := hmac.New(sha256.New, key)
+//	sig, _ := rsa.SignPSS(rand.Reader, priv, crypto.SHA256, digest, nil)
+//	token := rand.Text()
+//
+// MD5 and SHA-1 have practical collision attacks, so they must not protect
+// signatures or identify secrets. Non-security uses such as ETags and cache
+// keys are not flagged: the assignment patterns only fire when the result is
+// stored in a variable named like a signature, token, MAC, secret or password.
+//
+// See: https://pkg.go.dev/crypto/md5
+// See: https://pkg.go.dev/crypto/sha1
+func WeakHashSecurity(m dsl.Matcher) {
+	// HMAC-MD5 and HMAC keys derived from MD5/SHA-1
+	m.Match(
+		`hmac.New(md5.New, $_)`,
+	).
+		Report("HMAC-MD5 is not an approved MAC; use hmac.New(sha256.New, key) instead")
+
+	m.Match(
+		`hmac.New($_, $key)`,
+	).
+		Where(m["key"].Text.Matches(`(^|[^\w])(md5|sha1)\.`)).
+		Report("HMAC key derived from MD5 or SHA-1; derive keys with hkdf.Key or pbkdf2.Key (Go 1.24+) using SHA-256")
+
+	// Signatures over MD5/SHA-1 digests
+	m.Match(
+		`rsa.SignPKCS1v15($_, $_, crypto.MD5, $_)`,
+		`rsa.SignPKCS1v15($_, $_, crypto.SHA1, $_)`,
+		`rsa.SignPSS($_, $_, crypto.MD5, $_, $_)`,
+		`rsa.SignPSS($_, $_, crypto.SHA1, $_, $_)`,
+	).
+		Report("signature over an MD5 or SHA-1 digest is forgeable via collision attacks; use crypto.SHA256 or stronger")
+
+	m.Match(
+		`ecdsa.SignASN1($_, $_, $digest)`,
+		`ed25519.Sign($_, $digest)`,
+	).
+		Where(m["digest"].Text.Matches(`(^|[^\w])(md5|sha1)\.`)).
+		Report("signature over an MD5 or SHA-1 digest is forgeable via collision attacks; use sha256.Sum256 or stronger")
+
+	// Password input hashed with MD5/SHA-1
+	m.Match(
+		`md5.Sum($p)`,
+		`sha1.Sum($p)`,
+	).
+		Where(m["p"].Text.Matches(`(?i)(password|passwd|passphrase|pwd)`)).
+		Report("MD5 and SHA-1 are not suitable for passwords; use pbkdf2.Key (Go 1.24+), argon2.IDKey or bcrypt.GenerateFromPassword")
+
+	// Result stored as a signature, token, MAC, secret or password
+	m.Match(
+		`$x := md5.Sum($_)`, `$x = md5.Sum($_)`,
+		`$x := sha1.Sum($_)`, `$x = sha1.Sum($_)`,
+		`$x := md5.New()`, `$x = md5.New()`,
+		`$x := sha1.New()`, `$x = sha1.New()`,
+		`$x := fmt.Sprintf($_, md5.Sum($_))`, `$x = fmt.Sprintf($_, md5.Sum($_))`,
+		`$x := fmt.Sprintf($_, sha1.Sum($_))`, `$x = fmt.Sprintf($_, sha1.Sum($_))`,
+	).
+		Where(m["x"].Text.Matches(`(?i:signature|token|secret|password|passwd|apikey)|(^|[._])(?i:sig|mac|hmac|pwd)([A-Z_]|$)|(Sig|Mac|MAC|Hmac|HMAC|Pwd)([A-Z_]|$)`)).
+		Report("MD5 and SHA-1 are broken for security use; $x should be derived with SHA-256 or stronger (or crypto/rand for tokens)")
+}
+
+// PasswordHashing detects passwords hashed with a single round of a fast
+// general-purpose hash and suggests a password hashing function.
+//
+// Weak patterns:
+//
+//	sum := sha256.Sum256([]byte(password))
+//	sum := sha512.Sum512(append(salt, password...))
+//
+//	h := sha256.New()
+//	h.Write(salt)
+//	h.Write([]byte(password))
+//
+// Recommended:
+//
+//	key, err := pbkdf2.Key(sha256.New, password, salt, 600_000, 32)  // Go 1.24+, FIPS-approved
+//	key := argon2.IDKey([]byte(password), salt, 1, 64*1024, 4, 32)   // golang.org/x/crypto/argon2
+//	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+//
+// SHA-256 and SHA-512 are designed to be fast, so leaked digests can be
+// brute-forced at billions of guesses per second on a GPU. A salt defeats
+// precomputed tables but does not slow down guessing.
+//
+// See: https://pkg.go.dev/crypto/pbkdf2
+// See: https://pkg.go.dev/golang.org/x/crypto/argon2
+// See: https://pkg.go.dev/golang.org/x/crypto/bcrypt
+func PasswordHashing(m dsl.Matcher) {
+	m.Match(
+		`sha256.Sum256($p)`,
+		`sha256.Sum224($p)`,
+		`sha512.Sum512($p)`,
+		`sha512.Sum384($p)`,
+	).
+		Where(m["p"].Text.Matches(`(?i)(password|passwd|passphrase|pwd)`)).
+		Report("password hashed with a fast SHA-2 digest (salted or not) can be brute-forced; use pbkdf2.Key (Go 1.24+), argon2.IDKey or bcrypt.GenerateFromPassword")
+
+	m.Match(
+		`$h.Write($p)`,
+		`io.WriteString($h, $p)`,
+	).
+		Where(m["h"].Type.Is("hash.Hash") && m["p"].Text.Matches(`(?i)(password|passwd|passphrase|pwd)`)).
+		Report("password hashed with a fast general-purpose hash (salted or not) can be brute-forced; use pbkdf2.Key (Go 1.24+), argon2.IDKey or bcrypt.GenerateFromPassword")
+}
+
+// TLSInsecureSkipVerify detects tls.Config with certificate verification
+// disabled outside of test files.
+//
+// Insecure pattern:
+//
+//	cfg := &tls.Config{InsecureSkipVerify: true}
+//
+// Recommended:
+//
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(caPEM)
+//	cfg := &tls.Config{RootCAs: pool}
+//
+// InsecureSkipVerify disables both certificate chain and hostname
+// verification, so any on-path attacker can impersonate the server. Trust a
+// private CA through RootCAs, or use VerifyConnection for custom checks.
+//
+// See: https://pkg.go.dev/crypto/tls#Config
+func TLSInsecureSkipVerify(m dsl.Matcher) {
+	// The field is matched rather than the whole tls.Config literal, so the
+	// other TLS rules still report on the same literal.
+	m.Match(
+		`InsecureSkipVerify: true`,
+	).
+		Where(m["$$"].Node.Parent().Is("CompositeLit") && !m.File().Name.Matches(`_test\.go$`)).
+		Report("tls.Config.InsecureSkipVerify disables certificate and hostname verification: any man-in-the-middle can impersonate the server; trust the CA via RootCAs or use VerifyConnection instead")
+
+	m.Match(
+		`$cfg.InsecureSkipVerify = true`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config") && !m.File().Name.Matches(`_test\.go$`)).
+		Report("tls.Config.InsecureSkipVerify disables certificate and hostname verification: any man-in-the-middle can impersonate the server; trust the CA via RootCAs or use VerifyConnection instead")
+}
+
+// TLSMinVersion detects tls.Config.MinVersion set below TLS 1.2.
+//
+// Insecure pattern:
+//
+//	cfg := &tls.Config{MinVersion: tls.VersionTLS10}
+//
+// Recommended:
+//
+//	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+//	cfg := &tls.Config{MinVersion: tls.VersionTLS13}
+//
+// TLS 1.0 and 1.1 rely on SHA-1 and CBC constructions with known attacks
+// (BEAST, Lucky13) and were deprecated by RFC 8996. Go clients default to
+// TLS 1.2 since Go 1.18, and servers since Go 1.22, so an explicit lower
+// MinVersion re-enables them.
+//
+// See: https://pkg.go.dev/crypto/tls#Config
+// See: https://www.rfc-editor.org/rfc/rfc8996
+func TLSMinVersion(m dsl.Matcher) {
+	// 0x0303 (771) is tls.VersionTLS12
+	m.Match(
+		`MinVersion: $v`,
+	).
+		Where(m["$$"].Node.Parent().Is("CompositeLit") && m["v"].Type.Is("uint16") && m["v"].Value.Int() < 771).
+		Report("tls.Config.MinVersion $v enables TLS 1.0/1.1, which are deprecated (RFC 8996) and vulnerable to downgrade and CBC attacks; use tls.VersionTLS12 or higher")
+
+	m.Match(
+		`$cfg.MinVersion = $v`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config") && m["v"].Value.Int() < 771).
+		Report("tls.Config.MinVersion $v enables TLS 1.0/1.1, which are deprecated (RFC 8996) and vulnerable to downgrade and CBC attacks; use tls.VersionTLS12 or higher")
+}
+
+// TLSInsecureCipherSuites detects explicit CipherSuites lists containing
+// suites reported by tls.InsecureCipherSuites.
+//
+// Insecure pattern:
+//
+//	cfg := &tls.Config{
+//	    CipherSuites: []uint16{
+//	        tls.TLS_RSA_WITH_RC4_128_SHA,
+//	        tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA,
+//	    },
+//	}
+//
+// Recommended:
+//
+//	cfg := &tls.Config{}  // Go's default suite selection and ordering
+//
+// RC4 is broken, 3DES is vulnerable to Sweet32, and the CBC-SHA256 suites are
+// vulnerable to Lucky13-style timing attacks. Since Go 1.17 the suite order is
+// chosen by crypto/tls, so CipherSuites should usually be left unset.
+//
+// See: https://pkg.go.dev/crypto/tls#InsecureCipherSuites
+func TLSInsecureCipherSuites(m dsl.Matcher) {
+	m.Match(
+		`CipherSuites: $suites`,
+	).
+		Where(
+			m["$$"].Node.Parent().Is("CompositeLit") && m["suites"].Type.Is("[]uint16") &&
+				m["suites"].Text.Matches(`_RC4_|_3DES_|_CBC_SHA256\b`),
+		).
+		Report("tls.Config.CipherSuites includes suites from tls.InsecureCipherSuites (RC4, 3DES or CBC-SHA256), which are vulnerable to known attacks; remove them or leave CipherSuites unset")
+
+	m.Match(
+		`$cfg.CipherSuites = $suites`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config") && m["suites"].Text.Matches(`_RC4_|_3DES_|_CBC_SHA256\b`)).
+		Report("tls.Config.CipherSuites includes suites from tls.InsecureCipherSuites (RC4, 3DES or CBC-SHA256), which are vulnerable to known attacks; remove them or leave CipherSuites unset")
+}
+
+// DeprecatedTLSConfig detects deprecated tls.Config fields and methods.
+//
+// Deprecated patterns:
+//
+//	cfg := &tls.Config{PreferServerCipherSuites: true}
+//	cfg.NameToCertificate = map[string]*tls.Certificate{...}
+//	cfg.BuildNameToCertificate()
+//
+// Recommended:
+//
+//	cfg := &tls.Config{Certificates: certs}  // leaf selection is automatic
+//
+// PreferServerCipherSuites is ignored since Go 1.18, which can give a false
+// sense of control over the negotiated suite. NameToCertificate and
+// BuildNameToCertificate are deprecated since Go 1.14: crypto/tls picks the
+// first compatible entry of Certificates, and GetCertificate covers dynamic
+// selection.
+//
+// See: https://pkg.go.dev/crypto/tls#Config
+func DeprecatedTLSConfig(m dsl.Matcher) {
+	m.Match(
+		`PreferServerCipherSuites: $_`,
+	).
+		Where(m["$$"].Node.Parent().Is("CompositeLit")).
+		Report("tls.Config.PreferServerCipherSuites is deprecated and ignored since Go 1.18: crypto/tls orders suites itself based on hardware support; remove the field")
+
+	m.Match(
+		`$cfg.PreferServerCipherSuites = $_`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config")).
+		Report("tls.Config.PreferServerCipherSuites is deprecated and ignored since Go 1.18: crypto/tls orders suites itself based on hardware support; remove the assignment")
+
+	m.Match(
+		`NameToCertificate: $_`,
+	).
+		Where(m["$$"].Node.Parent().Is("CompositeLit")).
+		Report("tls.Config.NameToCertificate is deprecated since Go 1.14: certificate selection by SNI is automatic; use Certificates or GetCertificate instead")
+
+	m.Match(
+		`$cfg.NameToCertificate = $_`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config")).
+		Report("tls.Config.NameToCertificate is deprecated since Go 1.14: certificate selection by SNI is automatic; use Certificates or GetCertificate instead")
+
+	m.Match(
+		`$cfg.BuildNameToCertificate()`,
+	).
+		Where(m["cfg"].Type.Is("*tls.Config")).
+		Report("tls.Config.BuildNameToCertificate is deprecated since Go 1.14: certificate selection by SNI is automatic; remove the call")
+}
+
+// DeprecatedPEMEncryption detects legacy RFC 1423 PEM encryption in
+// crypto/x509, deprecated since Go 1.16.
+//
+// Deprecated patterns:
+//
+//	if x509.IsEncryptedPEMBlock(block) {
+//	    der, err := x509.DecryptPEMBlock(block, password)
+//	}
+//	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", der, password, x509.PEMCipherAES256)
+//
+// Recommended:
+//
+//	der, err := x509.MarshalPKCS8PrivateKey(key)
+//	// Encrypt der with an authenticated cipher (AES-GCM) under a key derived
+//	// with pbkdf2.Key or scrypt, or use encrypted PKCS#8 / age for storage.
+//
+// Security issue: Legacy PEM encryption does not authenticate the ciphertext,
+// so it is vulnerable to padding oracle attacks that can recover the
+// plaintext. Its key derivation is a single MD5 round over the password, so
+// the password is also cheap to brute-force.
+//
+// See: https://pkg.go.dev/crypto/x509#DecryptPEMBlock
+// See: https://pkg.go.dev/crypto/x509#MarshalPKCS8PrivateKey
+func DeprecatedPEMEncryption(m dsl.Matcher) {
+	m.Match(
+		`x509.IsEncryptedPEMBlock($block)`,
+	).
+		Report("x509.IsEncryptedPEMBlock is deprecated since Go 1.16: legacy PEM encryption is unauthenticated and vulnerable to padding oracle attacks; store keys as PKCS#8 encrypted with an authenticated cipher and a proper KDF")
+
+	m.Match(
+		`x509.DecryptPEMBlock($block, $password)`,
+	).
+		Report("x509.DecryptPEMBlock is deprecated since Go 1.16: legacy PEM encryption is unauthenticated and vulnerable to padding oracle attacks; store keys as PKCS#8 encrypted with an authenticated cipher and a proper KDF")
+
+	m.Match(
+		`x509.EncryptPEMBlock($rand, $typ, $data, $password, $alg)`,
+	).
+		Report("x509.EncryptPEMBlock is deprecated since Go 1.16: legacy PEM encryption is unauthenticated and vulnerable to padding oracle attacks, and derives its key with a single MD5 round; use x509.MarshalPKCS8PrivateKey with an authenticated cipher and pbkdf2.Key or scrypt")
+}
+
+// DeprecatedCRL detects deprecated crypto/x509 CRL parsing and creation
+// functions and suggests the RevocationList API.
+//
+// Deprecated patterns:
+//
+//	crl, err := x509.ParseCRL(data)
+//	crl, err := x509.ParseDERCRL(der)
+//	der, err := caCert.CreateCRL(rand.Reader, caKey, revoked, now, expiry)
+//
+// New pattern:
+//
+//	rl, err := x509.ParseRevocationList(der)  // Go 1.19+
+//	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+//	    Number:                    big.NewInt(1),
+//	    ThisUpdate:                now,
+//	    NextUpdate:                expiry,
+//	    RevokedCertificateEntries: entries,
+//	}, caCert, caKey)  // Go 1.15+
+//
+// Benefits:
+//   - Parses into x509.RevocationList with typed fields instead of pkix.CertificateList
+//   - CreateRevocationList produces RFC 5280 conformant v2 CRLs with a CRL number
+//     and authority key identifier
+//   - CheckSignatureFrom verifies the CRL issuer
+//
+// See: https://pkg.go.dev/crypto/x509#ParseRevocationList
+// See: https://pkg.go.dev/crypto/x509#CreateRevocationList
+func DeprecatedCRL(m dsl.Matcher) {
+	m.Match(
+		`x509.ParseCRL($data)`,
+	).
+		Report("x509.ParseCRL is deprecated; use x509.ParseRevocationList on the DER bytes instead (Go 1.19+)")
+
+	m.Match(
+		`x509.ParseDERCRL($der)`,
+	).
+		Report("x509.ParseDERCRL is deprecated; use x509.ParseRevocationList($der) instead (Go 1.19+); note it returns *x509.RevocationList rather than *pkix.CertificateList")
+
+	m.Match(
+		`$cert.CreateCRL($rand, $priv, $revoked, $now, $expiry)`,
+	).
+		Where(m["cert"].Type.Is("*x509.Certificate")).
+		Report("x509.Certificate.CreateCRL is deprecated: it produces non-conformant v1 CRLs; use x509.CreateRevocationList instead (Go 1.15+)")
+}
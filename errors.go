@@ -2,7 +2,11 @@
 
 package gorules
 
-import "github.com/quasilyte/go-ruleguard/dsl"
+import (
+	"strings"
+
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
 
 // ErrorsAsType detects errors.As with a pointer target and suggests errors.AsType.
 //
@@ -34,3 +38,517 @@ func ErrorsAsType(m dsl.Matcher) {
 	).
 		Report("use errors.AsType[$target]($err) instead of errors.As for type-safe, faster error assertion (Go 1.26+)")
 }
+
+// ErrorfWrapVerb detects fmt.Errorf calls that format an error without
+// wrapping it, wrap a value that is not an error, or have nothing to format.
+//
+// Broken patterns:
+//
+//	return fmt.Errorf("open config: %v", err)  // err is flattened to text
+//	return fmt.Errorf("bad id: %w", id)        // %w on a non-error (vet error)
+//	return fmt.Errorf("not found")             // nothing to format
+//
+// Correct patterns:
+//
+//	return fmt.Errorf("open config: %w", err)
+//	return fmt.Errorf("bad id: %v", id)
+//	return errors.New("not found")
+//
+// Only %w keeps the error in the chain seen by errors.Is and errors.AsType.
+// Since Go 1.20 a format may contain several %w verbs, so an error formatted
+// with %v is flagged even when another argument is already wrapped.
+//
+// Note: Calls with one or two arguments are checked; the error must be the
+// last argument for the %v/%s case.
+//
+// See: https://pkg.go.dev/fmt#Errorf
+// See: https://go.dev/doc/go1.20#errors
+func ErrorfWrapVerb(m dsl.Matcher) {
+	// %w applied to a non-error
+	m.Match(
+		`fmt.Errorf($f, $x)`,
+	).
+		Where(m["f"].Text.Matches(`^"[^%]*%w[^%]*"$`) && !m["x"].Type.Implements("error")).
+		Report("%w requires an error operand but $x is not an error; use %v")
+
+	m.Match(
+		`fmt.Errorf($f, $_, $x)`,
+	).
+		Where(m["f"].Text.Matches(`^"[^%]*%[^%]*%w[^%]*"$`) && !m["x"].Type.Implements("error")).
+		Report("%w requires an error operand but $x is not an error; use %v")
+
+	m.Match(
+		`fmt.Errorf($f, $x, $_)`,
+	).
+		Where(m["f"].Text.Matches(`^"[^%]*%w[^%]*%[^%]*"$`) && !m["x"].Type.Implements("error")).
+		Report("%w requires an error operand but $x is not an error; use %v")
+
+	// %v / %s applied to an error
+	m.Match(
+		`fmt.Errorf($f, $err)`,
+	).
+		Where(m["f"].Text.Matches(`^"[^%]*%[vs][^%]*"$`) && m["err"].Type.Implements("error")).
+		Report("use %w instead of %v or %s to wrap $err so errors.Is and errors.AsType can unwrap it")
+
+	m.Match(
+		`fmt.Errorf($f, $a, $err)`,
+	).
+		Where(
+			m["f"].Text.Matches(`^"[^%]*%[^%]*%[vs][^%]*"$`) && m["err"].Type.Implements("error") &&
+				(!m["a"].Type.Implements("error") || m["f"].Text.Matches(`%w`)),
+		).
+		Report("use %w instead of %v or %s to wrap $err so errors.Is and errors.AsType can unwrap it (multiple %w are allowed since Go 1.20)")
+
+	// Constant message with no arguments
+	m.Match(
+		`fmt.Errorf($s)`,
+	).
+		Where(m["s"].Const && !m["s"].Text.Matches(`%`)).
+		Report("use errors.New($s) instead of fmt.Errorf with no formatting arguments").
+		Suggest("errors.New($s)")
+}
+
+// ErrorsJoin detects third-party and hand-rolled multi-error aggregation and
+// suggests errors.Join or multiple %w verbs.
+//
+// Old patterns:
+//
+//	// hashicorp/go-multierror
+//	result = multierror.Append(result, err)
+//	return result.ErrorOrNil()
+//
+//	// uber-go/multierr
+//	err = multierr.Append(err, closeErr)
+//	err = multierr.Combine(err1, err2, err3)
+//
+//	// Joining messages by hand
+//	var msgs []string
+//	for _, err := range errs {
+//	    msgs = append(msgs, err.Error())
+//	}
+//	return errors.New(strings.Join(msgs, "; "))
+//
+//	// Formatting two errors with %v
+//	return fmt.Errorf("%v; %v", err1, err2)
+//
+// New pattern (Go 1.20+):
+//
+//	err = errors.Join(err, closeErr)
+//	return errors.Join(errs...)
+//	return fmt.Errorf("write: %w, close: %w", err1, err2)
+//
+// Benefits:
+//   - Standard library only, no third-party dependency
+//   - errors.Is and errors.As see every wrapped error
+//   - errors.Join discards nil errors and returns nil if all are nil
+//
+// The multierror.Append and multierr.Append/Combine calls map one-to-one
+// onto errors.Join and are autofixed. The result prints one error per line
+// instead of the library's own format.
+//
+// See: https://pkg.go.dev/errors#Join
+// See: https://go.dev/doc/go1.20#errors
+func ErrorsJoin(m dsl.Matcher) {
+	// hashicorp/go-multierror
+	m.Match(
+		`multierror.Append($err, $errs...)`,
+	).
+		Where(m.File().Imports("github.com/hashicorp/go-multierror")).
+		Report("use errors.Join($err, $errs...) instead of multierror.Append (Go 1.20+)").
+		Suggest("errors.Join($err, $errs...)")
+
+	m.Match(
+		`multierror.Append($*errs)`,
+	).
+		Where(m.File().Imports("github.com/hashicorp/go-multierror")).
+		Report("use errors.Join($errs) instead of multierror.Append (Go 1.20+)").
+		Suggest("errors.Join($errs)")
+
+	m.Match(
+		`$merr.ErrorOrNil()`,
+	).
+		Where(m.File().Imports("github.com/hashicorp/go-multierror")).
+		Report("errors.Join already returns nil when every error is nil; $merr.ErrorOrNil() is unnecessary after migrating to errors.Join (Go 1.20+)")
+
+	// uber-go/multierr
+	m.Match(
+		`multierr.Append($a, $b)`,
+	).
+		Where(m.File().Imports("go.uber.org/multierr")).
+		Report("use errors.Join($a, $b) instead of multierr.Append (Go 1.20+)").
+		Suggest("errors.Join($a, $b)")
+
+	m.Match(
+		`multierr.Combine($*errs)`,
+	).
+		Where(m.File().Imports("go.uber.org/multierr")).
+		Report("use errors.Join($errs) instead of multierr.Combine (Go 1.20+)").
+		Suggest("errors.Join($errs)")
+
+	m.Match(
+		`multierr.Errors($err)`,
+	).
+		Where(m.File().Imports("go.uber.org/multierr")).
+		Report("multierr.Errors can be replaced by asserting $err to interface{ Unwrap() []error } after migrating to errors.Join (Go 1.20+)")
+
+	// []error flattened into strings for strings.Join
+	m.Match(
+		`for _, $e := range $errs { $msgs = append($msgs, $e.Error()) }`,
+	).
+		Where(m["errs"].Type.Is("[]error")).
+		Report("use errors.Join($errs...) instead of joining $e.Error() strings; joined errors keep errors.Is/As working (Go 1.20+)")
+
+	// Two errors formatted with %v/%s and no %w
+	m.Match(
+		`fmt.Errorf($f, $e1, $e2)`,
+	).
+		Where(
+			m["f"].Text.Matches(`^".*%[vs].*%[vs].*"$`) && !m["f"].Text.Matches(`%w`) &&
+				m["e1"].Type.Implements("error") && m["e2"].Type.Implements("error"),
+		).
+		Report("both errors are flattened to text; use errors.Join($e1, $e2) or %w for each error so errors.Is/As still work (Go 1.20+)")
+}
+
+// ErrorsIsComparison detects sentinel errors compared with == or != and
+// suggests errors.Is.
+//
+// The old pattern:
+//
+//	if err == sql.ErrNoRows {
+//	    return nil
+//	}
+//	switch err {
+//	case fs.ErrNotExist:
+//	}
+//
+// New pattern (Go 1.13+):
+//
+//	if errors.Is(err, sql.ErrNoRows) {
+//	    return nil
+//	}
+//	switch {
+//	case errors.Is(err, fs.ErrNotExist):
+//	}
+//
+// Direct comparison stops matching as soon as any caller wraps the error with
+// fmt.Errorf("...: %w", err). errors.Is walks the whole wrap chain.
+//
+// Sentinels are recognized by the ErrXxx naming convention. io.EOF is only
+// flagged when it is compared against the result of a call other than a
+// reader method: the io.Reader contract requires Read to return io.EOF
+// itself, so `n, err := r.Read(buf); if err == io.EOF` is left alone.
+// Comparisons on a variable named target are skipped so that custom
+// Is(target error) methods are not flagged.
+//
+// See: https://pkg.go.dev/errors#Is
+func ErrorsIsComparison(m dsl.Matcher) {
+	m.Match(
+		`$err == $sentinel`,
+	).
+		Where(
+			m["err"].Type.Implements("error") && m["sentinel"].Type.Implements("error") &&
+				m["sentinel"].Text.Matches(`(^|\.)Err[A-Z0-9]\w*$`) &&
+				!m["err"].Text.Matches(`^target$`),
+		).
+		Report("use errors.Is($err, $sentinel) instead of == so wrapped errors still match").
+		Suggest("errors.Is($err, $sentinel)")
+
+	m.Match(
+		`$err != $sentinel`,
+	).
+		Where(
+			m["err"].Type.Implements("error") && m["sentinel"].Type.Implements("error") &&
+				m["sentinel"].Text.Matches(`(^|\.)Err[A-Z0-9]\w*$`) &&
+				!m["err"].Text.Matches(`^target$`),
+		).
+		Report("use !errors.Is($err, $sentinel) instead of != so wrapped errors still match").
+		Suggest("!errors.Is($err, $sentinel)")
+
+	// io.EOF from anything other than a reader method
+	m.Match(
+		`$_, $err := $f($*_); if $err == io.EOF { $*_ }`,
+		`$_, $err = $f($*_); if $err == io.EOF { $*_ }`,
+		`$err := $f($*_); if $err == io.EOF { $*_ }`,
+		`$err = $f($*_); if $err == io.EOF { $*_ }`,
+	).
+		Where(!m["f"].Text.Matches(`\.(Read\w*|Next\w*|Decode|Token|Scan)$|^io\.(ReadFull|ReadAtLeast)$`)).
+		Report("$f is not an io.Reader method and may wrap io.EOF; use errors.Is($err, io.EOF)")
+
+	// switch on an error value is a chain of == comparisons
+	// At least one case other than nil; the regexp spells out "not nil"
+	// because RE2 has no lookahead.
+	m.Match(
+		`switch $err { $*cases }`,
+	).
+		Where(m["err"].Type.Is("error") && m["cases"].Text.Matches(`case\s+([^n\s]|n[^i]|ni[^l]|nil\s*,)`)).
+		Report("switch on $err compares with ==; use a tagless switch with errors.Is($err, ...) cases so wrapped errors still match")
+}
+
+// ErrorTypeAssertion detects type assertions and type switches on error
+// values and suggests errors.AsType.
+//
+// The old pattern:
+//
+//	if pathErr, ok := err.(*fs.PathError); ok {
+//	    fmt.Println(pathErr.Path)
+//	}
+//	switch e := err.(type) {
+//	case *fs.PathError:
+//	}
+//
+// New pattern (Go 1.26+):
+//
+//	if pathErr, ok := errors.AsType[*fs.PathError](err); ok {
+//	    fmt.Println(pathErr.Path)
+//	}
+//
+// A type assertion only inspects the outermost error, so it fails as soon as
+// the error is wrapped. errors.AsType walks the wrap chain. The two-value
+// assertion maps directly onto errors.AsType and is autofixed.
+//
+// See: https://pkg.go.dev/errors#AsType
+func ErrorTypeAssertion(m dsl.Matcher) {
+	// errors.AsType[E error] only accepts types implementing error, so
+	// assertions to other interfaces (Timeout() bool, Unwrap() []error) are
+	// left alone.
+	m.Match(
+		`$v, $ok := $err.($typ)`,
+	).
+		Where(m["err"].Type.Is("error") && m["typ"].Type.Implements("error")).
+		Report("use errors.AsType[$typ]($err) instead of a type assertion so wrapped errors still match (Go 1.26+)").
+		Suggest("$v, $ok := errors.AsType[$typ]($err)")
+
+	m.Match(
+		`$v, $ok = $err.($typ)`,
+	).
+		Where(m["err"].Type.Is("error") && m["typ"].Type.Implements("error")).
+		Report("use errors.AsType[$typ]($err) instead of a type assertion so wrapped errors still match (Go 1.26+)").
+		Suggest("$v, $ok = errors.AsType[$typ]($err)")
+
+	m.Match(
+		`switch $_ := $err.(type) { $*_ }`,
+		`switch $err.(type) { $*_ }`,
+	).
+		Where(m["err"].Type.Is("error")).
+		Report("type switch on $err only inspects the outermost error; use errors.AsType[T]($err) for each case so wrapped errors still match (Go 1.26+)")
+}
+
+// ErrorStringMatching detects error handling that matches on err.Error()
+// text and suggests errors.Is with the standard sentinel where one exists.
+//
+// Fragile patterns:
+//
+//	if strings.Contains(err.Error(), "use of closed network connection") { ... }
+//	if err.Error() == "EOF" { ... }
+//	if strings.HasPrefix(err.Error(), "context deadline") { ... }
+//
+// New pattern:
+//
+//	if errors.Is(err, net.ErrClosed) { ... }
+//	if errors.Is(err, io.EOF) { ... }
+//	if errors.Is(err, context.DeadlineExceeded) { ... }
+//
+// Error text is not part of any API contract: it changes between releases,
+// differs across platforms and breaks as soon as a caller adds context with
+// fmt.Errorf. Well-known messages are mapped to their sentinels:
+//
+//	"use of closed network connection"  net.ErrClosed
+//	"EOF" / "unexpected EOF"            io.EOF / io.ErrUnexpectedEOF
+//	"context deadline exceeded"         context.DeadlineExceeded
+//	"context canceled"                  context.Canceled
+//	"i/o timeout"                       os.ErrDeadlineExceeded
+//	"http: Server closed"               http.ErrServerClosed
+//	"file already closed"               os.ErrClosed
+//	"no such file or directory"         fs.ErrNotExist
+//	"file exists"                       fs.ErrExist
+//	"permission denied"                 fs.ErrPermission
+//	"sql: no rows in result set"        sql.ErrNoRows
+//	"connection refused"                syscall.ECONNREFUSED
+//	"connection reset by peer"          syscall.ECONNRESET
+//	"broken pipe"                       syscall.EPIPE
+//
+// Any other constant string gets a generic finding.
+//
+// See: https://pkg.go.dev/errors#Is
+// See: https://pkg.go.dev/net#ErrClosed
+func ErrorStringMatching(m dsl.Matcher) {
+	m.Match(
+		`strings.Contains($e.Error(), $s)`,
+		`strings.HasPrefix($e.Error(), $s)`,
+		`strings.HasSuffix($e.Error(), $s)`,
+		`strings.EqualFold($e.Error(), $s)`,
+		`$e.Error() == $s`, `$s == $e.Error()`,
+		`$e.Error() != $s`, `$s != $e.Error()`,
+	).
+		Where(m["e"].Type.Implements("error") && m["s"].Const).
+		Do(errorStringReport)
+}
+
+// errorStringReport looks the matched message up in a table of well-known
+// error texts and names the sentinel to use with errors.Is.
+func errorStringReport(ctx *dsl.DoContext) {
+	// One `"message" sentinel [min Go version]` entry per line. Do callbacks
+	// can't use map literals, so the table is scanned as a string.
+	table := `
+"use of closed network connection" net.ErrClosed 1.16
+"closed network connection" net.ErrClosed 1.16
+"unexpected EOF" io.ErrUnexpectedEOF
+"EOF" io.EOF
+"context deadline exceeded" context.DeadlineExceeded
+"context deadline" context.DeadlineExceeded
+"context canceled" context.Canceled
+"i/o timeout" os.ErrDeadlineExceeded 1.15
+"http: Server closed" http.ErrServerClosed
+"file already closed" os.ErrClosed
+"no such file or directory" fs.ErrNotExist 1.16
+"file does not exist" fs.ErrNotExist 1.16
+"file exists" fs.ErrExist 1.16
+"file already exists" fs.ErrExist 1.16
+"permission denied" fs.ErrPermission 1.16
+"sql: no rows in result set" sql.ErrNoRows
+"no rows in result set" sql.ErrNoRows
+"connection refused" syscall.ECONNREFUSED
+"connection reset by peer" syscall.ECONNRESET
+"broken pipe" syscall.EPIPE
+`
+	e := ctx.Var("e").Text()
+	key := "\n" + ctx.Var("s").Text() + " "
+	for table != "" && !strings.HasPrefix(table, key) {
+		table = table[1:]
+	}
+	if table == "" {
+		ctx.SetReport("matching " + e + ".Error() text is fragile; match on a typed error or sentinel with errors.Is or errors.AsType instead")
+		return
+	}
+	table = table[len(key):]
+	sentinel := ""
+	for !strings.HasPrefix(table, " ") && !strings.HasPrefix(table, "\n") {
+		sentinel = sentinel + table[:1]
+		table = table[1:]
+	}
+	version := ""
+	if strings.HasPrefix(table, " ") {
+		table = table[1:]
+		for !strings.HasPrefix(table, "\n") {
+			version = version + table[:1]
+			table = table[1:]
+		}
+		version = " (Go " + version + "+)"
+	}
+	ctx.SetReport("use errors.Is(" + e + ", " + sentinel + ") instead of matching " + e + ".Error() text" + version)
+}
+
+// StdlibSentinels detects home-grown sentinel errors whose message duplicates
+// a standard library sentinel and suggests reusing or wrapping it.
+//
+// Old pattern:
+//
+//	var ErrNotSupported = errors.New("not supported")
+//	var ErrAlreadyFinished = errors.New("process already finished")
+//
+// New pattern:
+//
+//	var ErrNotSupported = errors.ErrUnsupported                             // alias (Go 1.21+)
+//	var ErrNotSupported = fmt.Errorf("mydriver: %w", errors.ErrUnsupported) // wrap
+//	var ErrAlreadyFinished = os.ErrProcessDone
+//
+// Benefits:
+//   - errors.Is(err, errors.ErrUnsupported) works across library boundaries
+//   - Callers don't need to import your package just to compare errors
+//   - One canonical sentinel per condition
+//
+// Messages mapped to sentinels (case-insensitive):
+//
+//	"not supported", "unsupported operation"  errors.ErrUnsupported
+//	"process already finished"                os.ErrProcessDone
+//	"file already closed"                     fs.ErrClosed
+//	"file does not exist"                     fs.ErrNotExist
+//	"file already exists"                     fs.ErrExist
+//	"permission denied"                       fs.ErrPermission
+//	"invalid argument"                        fs.ErrInvalid
+//	"use of closed network connection"        net.ErrClosed
+//	"i/o timeout", "deadline exceeded"        os.ErrDeadlineExceeded
+//	"unexpected EOF"                          io.ErrUnexpectedEOF
+//	"short write"                             io.ErrShortWrite
+//	"read/write on closed pipe"               io.ErrClosedPipe
+//
+// See: https://pkg.go.dev/errors#ErrUnsupported
+// See: https://pkg.go.dev/io/fs#pkg-variables
+func StdlibSentinels(m dsl.Matcher) {
+	// Only package-level var declarations (var ErrX = errors.New(...)),
+	// grouped or not, are checked; locals and errors built inside function
+	// bodies are left alone.
+
+	// errors.ErrUnsupported (Go 1.21) for operations a type or platform can't perform
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:(not supported|unsupported|unsupported operation|operation (is )?not supported))"$`)).
+		Report("errors.New($msg) duplicates errors.ErrUnsupported; use errors.ErrUnsupported directly or wrap it with %w so errors.Is works across packages (Go 1.21+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:(os: )?process already (finished|done))"$`)).
+		Report("errors.New($msg) duplicates os.ErrProcessDone; use os.ErrProcessDone directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:file (already )?closed)"$`)).
+		Report("errors.New($msg) duplicates fs.ErrClosed; use fs.ErrClosed directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:file does not exist)"$`)).
+		Report("errors.New($msg) duplicates fs.ErrNotExist; use fs.ErrNotExist directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:file already exists)"$`)).
+		Report("errors.New($msg) duplicates fs.ErrExist; use fs.ErrExist directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:permission denied)"$`)).
+		Report("errors.New($msg) duplicates fs.ErrPermission; use fs.ErrPermission directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:invalid argument)"$`)).
+		Report("errors.New($msg) duplicates fs.ErrInvalid; use fs.ErrInvalid directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:use of closed network connection)"$`)).
+		Report("errors.New($msg) duplicates net.ErrClosed; use net.ErrClosed directly or wrap it with %w so errors.Is works across packages (Go 1.16+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:(i/o timeout|deadline exceeded))"$`)).
+		Report("errors.New($msg) duplicates os.ErrDeadlineExceeded; use os.ErrDeadlineExceeded directly or wrap it with %w so errors.Is works across packages (Go 1.15+)")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:unexpected EOF)"$`)).
+		Report("errors.New($msg) duplicates io.ErrUnexpectedEOF; use io.ErrUnexpectedEOF directly or wrap it with %w so errors.Is works across packages")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:short write)"$`)).
+		Report("errors.New($msg) duplicates io.ErrShortWrite; use io.ErrShortWrite directly or wrap it with %w so errors.Is works across packages")
+
+	m.Match(
+		`$name $*_ = errors.New($msg)`,
+	).
+		Where(m["name"].Object.IsGlobal() && m["msg"].Text.Matches(`^"(?i:(io: )?read/write on closed pipe)"$`)).
+		Report("errors.New($msg) duplicates io.ErrClosedPipe; use io.ErrClosedPipe directly or wrap it with %w so errors.Is works across packages")
+}
@@ -0,0 +1,231 @@
+//go:build ruleguard
+
+// Package gorules defines the opt-in FIPS 140-3 rule profile.
+//
+// These rules flag cryptographic primitives outside the FIPS 140-3 approved
+// set. They are not loaded by the default rules glob; enable them alongside
+// the core rules:
+//
+//	rules: "${config-path}/rules/*.go,${config-path}/rules/fips/*.go"
+//
+// Every finding is prefixed with "FIPS 140-3:" and names the reason the
+// primitive is not approved. Where a core rule already reports a call
+// (WeakRSAKeySize, DeprecatedPKCS1v15, WeakHashSecurity), the FIPS rule
+// matches the enclosing statement instead, so both findings appear on the
+// same line. Set issues.uniq-by-line to false to see both in golangci-lint.
+// md5.Sum on password-named input is reported by WeakHashSecurity only.
+package gorules
+
+import "github.com/quasilyte/go-ruleguard/dsl"
+
+// FIPSApprovedHashes detects MD5 anywhere and SHA-1 in signatures, which are
+// not approved under FIPS 140-3.
+//
+// Non-approved patterns:
+//
+//	sum := md5.Sum(data)
+//	sig, _ := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA1, digest)
+//	tmpl := &x509.Certificate{SignatureAlgorithm: x509.SHA1WithRSA}
+//
+// Approved alternatives:
+//
+//	sum := sha256.Sum256(data)
+//	sig, _ := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+//	tmpl := &x509.Certificate{SignatureAlgorithm: x509.SHA256WithRSA}
+//
+// MD5 is not an approved hash function. SHA-1 remains approved for some
+// legacy verification uses but is disallowed for generating digital
+// signatures (NIST SP 800-131A Rev. 2).
+//
+// See: https://go.dev/doc/security/fips140
+func FIPSApprovedHashes(m dsl.Matcher) {
+	m.Match(
+		`md5.New()`,
+		`md5.Sum($_)`,
+	).
+		Report("FIPS 140-3: MD5 is not an approved hash function; use SHA-256 or SHA-3")
+
+	// Matched on the enclosing statement: WeakHashSecurity already claims
+	// the call expression, and ruleguard reports only the first rule that
+	// matches a given call.
+	m.Match(
+		`$*_ = rsa.SignPKCS1v15($_, $_, crypto.SHA1, $_)`,
+		`$*_ := rsa.SignPKCS1v15($_, $_, crypto.SHA1, $_)`,
+		`return rsa.SignPKCS1v15($_, $_, crypto.SHA1, $_)`,
+		`$*_ = rsa.SignPSS($_, $_, crypto.SHA1, $_, $_)`,
+		`$*_ := rsa.SignPSS($_, $_, crypto.SHA1, $_, $_)`,
+		`return rsa.SignPSS($_, $_, crypto.SHA1, $_, $_)`,
+	).
+		Report("FIPS 140-3: SHA-1 is disallowed for signature generation (SP 800-131A Rev. 2); use crypto.SHA256 or stronger")
+
+	m.Match(
+		`x509.Certificate{$*_, SignatureAlgorithm: $alg, $*_}`,
+		`x509.CertificateRequest{$*_, SignatureAlgorithm: $alg, $*_}`,
+		`x509.RevocationList{$*_, SignatureAlgorithm: $alg, $*_}`,
+	).
+		Where(m["alg"].Text.Matches(`(MD5|SHA1)With|WithSHA1$`)).
+		Report("FIPS 140-3: $alg signs with MD5 or SHA-1, which is disallowed for signature generation (SP 800-131A Rev. 2); use a SHA-256 based algorithm")
+}
+
+// FIPSApprovedCiphers detects ciphers and padding schemes outside the FIPS
+// 140-3 approved set.
+//
+// Non-approved patterns:
+//
+//	c, _ := rc4.NewCipher(key)
+//	b, _ := des.NewCipher(key)
+//	b, _ := des.NewTripleDESCipher(key)
+//	ct, _ := rsa.EncryptPKCS1v15(rand.Reader, pub, msg)
+//
+// Approved alternatives:
+//
+//	b, _ := aes.NewCipher(key)
+//	aead, _ := cipher.NewGCM(b)
+//	ct, _ := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil)
+//
+// RC4 and single DES were never approved. Three-key Triple DES encryption was
+// withdrawn at the end of 2023 (SP 800-67 Rev. 2). RSA PKCS#1 v1.5
+// encryption is disallowed for key transport after 2023 (SP 800-131A Rev. 2).
+//
+// See: https://go.dev/doc/security/fips140
+func FIPSApprovedCiphers(m dsl.Matcher) {
+	m.Match(
+		`rc4.NewCipher($_)`,
+	).
+		Report("FIPS 140-3: RC4 is not an approved cipher; use AES-GCM")
+
+	m.Match(
+		`des.NewCipher($_)`,
+	).
+		Report("FIPS 140-3: DES is not an approved cipher; use AES-GCM")
+
+	m.Match(
+		`des.NewTripleDESCipher($_)`,
+	).
+		Report("FIPS 140-3: Triple DES encryption was withdrawn after 2023 (SP 800-67 Rev. 2); use AES-GCM")
+
+	// Statement forms: DeprecatedPKCS1v15 claims the call expressions
+	m.Match(
+		`$*_ = rsa.EncryptPKCS1v15($_, $_, $_)`,
+		`$*_ := rsa.EncryptPKCS1v15($_, $_, $_)`,
+		`return rsa.EncryptPKCS1v15($_, $_, $_)`,
+		`$*_ = rsa.DecryptPKCS1v15($_, $_, $_)`,
+		`$*_ := rsa.DecryptPKCS1v15($_, $_, $_)`,
+		`return rsa.DecryptPKCS1v15($_, $_, $_)`,
+		`$*_ = rsa.DecryptPKCS1v15SessionKey($_, $_, $_, $_)`,
+		`$*_ := rsa.DecryptPKCS1v15SessionKey($_, $_, $_, $_)`,
+		`return rsa.DecryptPKCS1v15SessionKey($_, $_, $_, $_)`,
+	).
+		Report("FIPS 140-3: RSA PKCS#1 v1.5 encryption is disallowed for key transport (SP 800-131A Rev. 2); use rsa.EncryptOAEP")
+}
+
+// FIPSApprovedKeys detects key sizes and key agreement curves outside the
+// FIPS 140-3 approved set.
+//
+// Non-approved patterns:
+//
+//	key, _ := rsa.GenerateKey(rand.Reader, 1024)
+//	priv, _ := ecdh.X25519().GenerateKey(rand.Reader)
+//
+// Approved alternatives:
+//
+//	key, _ := rsa.GenerateKey(rand.Reader, 3072)
+//	priv, _ := ecdh.P256().GenerateKey(rand.Reader)
+//
+// FIPS 186-5 requires RSA moduli of at least 2048 bits. X25519 is not an
+// approved key agreement scheme (SP 800-56A Rev. 3 lists only the NIST
+// curves). Ed25519 signatures from crypto/ed25519 are approved under
+// FIPS 186-5 and are not flagged.
+//
+// See: https://go.dev/doc/security/fips140
+func FIPSApprovedKeys(m dsl.Matcher) {
+	// Statement forms: WeakRSAKeySize claims the call expression
+	m.Match(
+		`$*_ = rsa.GenerateKey($_, $bits)`,
+		`$*_ := rsa.GenerateKey($_, $bits)`,
+		`return rsa.GenerateKey($_, $bits)`,
+	).
+		Where(m["bits"].Value.Int() < 2048).
+		Report("FIPS 140-3: RSA key size $bits is below the 2048-bit minimum of FIPS 186-5; use 2048 bits or more")
+
+	m.Match(
+		`ecdh.X25519()`,
+	).
+		Report("FIPS 140-3: X25519 is not an approved key agreement scheme (SP 800-56A Rev. 3); use ecdh.P256(), P384() or P521()")
+}
+
+// FIPSValidatedModule detects golang.org/x/crypto primitives, which are
+// outside the Go Cryptographic Module and not FIPS 140-3 validated.
+//
+// Non-approved patterns:
+//
+//	aead, _ := chacha20poly1305.New(key)
+//	hash, _ := bcrypt.GenerateFromPassword(pw, bcrypt.DefaultCost)
+//	key := argon2.IDKey(pw, salt, 1, 64*1024, 4, 32)
+//	shared, _ := curve25519.X25519(priv, peer)
+//
+// Approved alternatives:
+//
+//	aead, _ := cipher.NewGCM(block)                            // AES-GCM
+//	key, _ := pbkdf2.Key(sha256.New, pw, salt, 600_000, 32)     // crypto/pbkdf2, Go 1.24+
+//	shared, _ := priv.ECDH(peer)                               // crypto/ecdh with a NIST curve
+//
+// Only the standard library crypto packages run inside the validated module
+// when GODEBUG=fips140=on. ChaCha20-Poly1305, bcrypt, scrypt, Argon2, BLAKE2
+// and Curve25519 are also not approved algorithms.
+//
+// See: https://go.dev/doc/security/fips140
+func FIPSValidatedModule(m dsl.Matcher) {
+	m.Match(
+		`chacha20poly1305.New($_)`,
+		`chacha20poly1305.NewX($_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/chacha20poly1305")).
+		Report("FIPS 140-3: ChaCha20-Poly1305 is not an approved AEAD and x/crypto is outside the validated module; use AES-GCM from crypto/cipher")
+
+	m.Match(
+		`chacha20.NewUnauthenticatedCipher($_, $_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/chacha20")).
+		Report("FIPS 140-3: ChaCha20 is not an approved cipher and x/crypto is outside the validated module; use AES-GCM from crypto/cipher")
+
+	m.Match(
+		`bcrypt.GenerateFromPassword($_, $_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/bcrypt")).
+		Report("FIPS 140-3: bcrypt is not an approved password-based KDF; use pbkdf2.Key from crypto/pbkdf2 (Go 1.24+)")
+
+	m.Match(
+		`scrypt.Key($*_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/scrypt")).
+		Report("FIPS 140-3: scrypt is not an approved password-based KDF; use pbkdf2.Key from crypto/pbkdf2 (Go 1.24+)")
+
+	m.Match(
+		`argon2.Key($*_)`,
+		`argon2.IDKey($*_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/argon2")).
+		Report("FIPS 140-3: Argon2 is not an approved password-based KDF; use pbkdf2.Key from crypto/pbkdf2 (Go 1.24+)")
+
+	m.Match(
+		`curve25519.X25519($_, $_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/curve25519")).
+		Report("FIPS 140-3: Curve25519 is not an approved key agreement scheme (SP 800-56A Rev. 3); use crypto/ecdh with P-256 or stronger")
+
+	m.Match(
+		`blake2b.$_($*_)`,
+		`blake2s.$_($*_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/blake2b") || m.File().Imports("golang.org/x/crypto/blake2s")).
+		Report("FIPS 140-3: BLAKE2 is not an approved hash function; use SHA-256, SHA-512 or SHA-3")
+
+	m.Match(
+		`hkdf.New($*_)`,
+		`hkdf.Extract($*_)`,
+		`hkdf.Expand($*_)`,
+	).
+		Where(m.File().Imports("golang.org/x/crypto/hkdf")).
+		Report("FIPS 140-3: golang.org/x/crypto/hkdf is outside the validated module; use crypto/hkdf (Go 1.24+)")
+}
@@ -2,7 +2,11 @@
 
 package gorules
 
-import "github.com/quasilyte/go-ruleguard/dsl"
+import (
+	"strings"
+
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
 
 // JoinHostPort detects fmt.Sprintf patterns for host:port and suggests net.JoinHostPort.
 //
@@ -17,11 +21,20 @@ import "github.com/quasilyte/go-ruleguard/dsl"
 // net.JoinHostPort properly handles IPv6 addresses by wrapping them in brackets,
 // which fmt.Sprintf does not. This is critical for network code correctness.
 //
-// Note: This rule only flags patterns with integer ports to reduce false positives.
-// String concatenation patterns like "host + : + port" are too common for non-network
-// use cases (cache keys, identifiers, etc.) and are not flagged.
+// String concatenation (host + ":" + port) is also common for cache keys and
+// identifiers, so it is only flagged when both operands are strings and the
+// result feeds net.Dial, net.DialTimeout, net.Listen, an Addr or Host field
+// (http.Server, url.URL), directly or through a variable used in the next
+// statement.
+//
+// The reverse bug, cutting a host:port at the first or last ":", breaks on
+// IPv6 literals and is reported with a suggestion to use net.SplitHostPort.
+// Splits are matched by their source (http.Request.Host/RemoteAddr,
+// url.URL.Host, http.Server.Addr, net.Conn and net.Listener addresses), not
+// by a sink, and addresses copied into a local variable first are missed.
 //
 // See: https://pkg.go.dev/net#JoinHostPort
+// See: https://pkg.go.dev/net#SplitHostPort
 func JoinHostPort(m dsl.Matcher) {
 	// Only flag fmt.Sprintf with integer port - this is a strong signal for network addresses
 	// String ports could be cache keys, identifiers, etc.
@@ -30,6 +43,83 @@ func JoinHostPort(m dsl.Matcher) {
 		`fmt.Sprintf("%v:%d", $host, $port)`,
 	).
 		Report("use net.JoinHostPort($host, strconv.Itoa($port)) instead of fmt.Sprintf for host:port (handles IPv6 correctly)")
+
+	// String concatenation feeding a network address sink
+	m.Match(
+		`net.Dial($_, $host + ":" + $port)`,
+		`net.DialTimeout($_, $host + ":" + $port, $_)`,
+		`net.Listen($_, $host + ":" + $port)`,
+		`net.Dial($_, fmt.Sprintf("%s:%s", $host, $port))`,
+		`net.DialTimeout($_, fmt.Sprintf("%s:%s", $host, $port), $_)`,
+		`net.Listen($_, fmt.Sprintf("%s:%s", $host, $port))`,
+	).
+		Where(m["host"].Type.Is("string") && m["port"].Type.Is("string")).
+		Report("use net.JoinHostPort($host, $port) to build the address (handles IPv6 correctly)")
+
+	// Addr and Host fields are matched on their own so the enclosing
+	// http.Server literal stays free for HTTPServerTimeouts. The literal's
+	// type is not visible from the field, so any Addr or Host field counts.
+	m.Match(
+		`Addr: $host + ":" + $port`,
+		`Host: $host + ":" + $port`,
+		`Addr: fmt.Sprintf("%s:%s", $host, $port)`,
+		`Host: fmt.Sprintf("%s:%s", $host, $port)`,
+	).
+		Where(m["$$"].Node.Parent().Is("CompositeLit") && m["host"].Type.Is("string") && m["port"].Type.Is("string")).
+		Report("use net.JoinHostPort($host, $port) to build the address (handles IPv6 correctly)")
+
+	m.Match(`$u.Host = $host + ":" + $port`).
+		Where(m["host"].Type.Is("string") && m["port"].Type.Is("string") && m["u"].Type.Is("*url.URL")).
+		Report("use net.JoinHostPort($host, $port) to build the address (handles IPv6 correctly)")
+
+	m.Match(`$srv.Addr = $host + ":" + $port`).
+		Where(m["host"].Type.Is("string") && m["port"].Type.Is("string") && m["srv"].Type.Is("*http.Server")).
+		Report("use net.JoinHostPort($host, $port) to build the address (handles IPv6 correctly)")
+
+	m.Match(
+		`$addr := $host + ":" + $port; $_, $_ := net.Dial($_, $addr)`,
+		`$addr := $host + ":" + $port; $_, $_ := net.Listen($_, $addr)`,
+		`$addr := $host + ":" + $port; $_, $_ = net.Dial($_, $addr)`,
+		`$addr := $host + ":" + $port; $_, $_ = net.Listen($_, $addr)`,
+		`$addr = $host + ":" + $port; $_, $_ := net.Dial($_, $addr)`,
+		`$addr = $host + ":" + $port; $_, $_ := net.Listen($_, $addr)`,
+		`$addr = $host + ":" + $port; $_, $_ = net.Dial($_, $addr)`,
+		`$addr = $host + ":" + $port; $_, $_ = net.Listen($_, $addr)`,
+	).
+		Where(m["host"].Type.Is("string") && m["port"].Type.Is("string")).
+		Report("use $addr := net.JoinHostPort($host, $port) to build the address (handles IPv6 correctly)")
+
+	// Splitting host:port by hand. A split host rarely goes straight back to
+	// a dial, so these are filtered on where the address comes from: request
+	// and URL hosts, server addresses, and connection or listener addresses.
+	m.Match(
+		`strings.Split($x.$f, ":")[$_]`,
+		`strings.SplitN($x.$f, ":", 2)[$_]`,
+		`$x.$f[:strings.Index($x.$f, ":")]`,
+		`$x.$f[:strings.LastIndex($x.$f, ":")]`,
+		`$x.$f[strings.Index($x.$f, ":")+1:]`,
+		`$x.$f[strings.LastIndex($x.$f, ":")+1:]`,
+		`$_ := strings.Index($x.$f, ":")`,
+		`$_ := strings.LastIndex($x.$f, ":")`,
+	).
+		Where(
+			(m["x"].Type.Is("*http.Request") && m["f"].Text.Matches(`^(Host|RemoteAddr)$`)) ||
+				(m["x"].Type.Is("*url.URL") && m["f"].Text == "Host") ||
+				(m["x"].Type.Is("*http.Server") && m["f"].Text == "Addr"),
+		).
+		Report("splitting $x.$f on \":\" breaks on IPv6 literals; use host, port, err := net.SplitHostPort($x.$f)")
+
+	m.Match(
+		`strings.Split($c.$f().String(), ":")[$_]`,
+		`strings.SplitN($c.$f().String(), ":", 2)[$_]`,
+		`$_ := strings.Index($c.$f().String(), ":")`,
+		`$_ := strings.LastIndex($c.$f().String(), ":")`,
+	).
+		Where(
+			(m["c"].Type.Implements("net.Conn") && m["f"].Text.Matches(`^(RemoteAddr|LocalAddr)$`)) ||
+				(m["c"].Type.Implements("net.Listener") && m["f"].Text == "Addr"),
+		).
+		Report("splitting $c.$f().String() on \":\" breaks on IPv6 literals; use host, port, err := net.SplitHostPort($c.$f().String())")
 }
 
 // FilepathIsLocal detects simple path traversal checks that could use filepath.IsLocal.
@@ -114,7 +204,386 @@ func DeprecatedReverseProxyDirector(m dsl.Matcher) {
 		Report("httputil.ReverseProxy.Director is deprecated in Go 1.26: Director is vulnerable to hop-by-hop header abuse; use Rewrite instead for safe header handling")
 }
 
-// ErrorBeforeUse detects potential nil pointer dereference before error check.
+// ServeMuxMethodPattern detects method guards at the top of handlers
+// registered with HandleFunc and suggests Go 1.22 method patterns.
+//
+// The old pattern:
+//
+//	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+//	    if r.Method != http.MethodPost {
+//	        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+//	        return
+//	    }
+//	    createUser(w, r)
+//	})
+//
+// New pattern (Go 1.22+):
+//
+//	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+//	    createUser(w, r)
+//	})
+//
+// Benefits:
+//   - ServeMux answers 405 with an Allow header for other methods
+//   - GET patterns also match HEAD
+//   - Routing is visible at registration instead of inside each handler
+//
+// The report names the route to register, e.g. "POST /users".
+//
+// Note: Only func literals passed directly to HandleFunc are inspected.
+//
+// See: https://pkg.go.dev/net/http#hdr-Patterns-ServeMux
+func ServeMuxMethodPattern(m dsl.Matcher) {
+	m.Match(`$mux.HandleFunc($path, func($w http.ResponseWriter, $r *http.Request) { if $r.Method != $method { $*_ }; $*_ })`).
+		Where(
+			(m["mux"].Type.Is("*http.ServeMux") || m["mux"].Text == "http") &&
+				m["method"].Text.Matches(`^(http\.Method(Get|Head|Post|Put|Patch|Delete|Connect|Options|Trace)|"(GET|HEAD|POST|PUT|PATCH|DELETE|CONNECT|OPTIONS|TRACE)")$`),
+		).
+		Do(serveMuxMethodReport)
+}
+
+// serveMuxMethodReport builds the ServeMuxMethodPattern report with the
+// method and a literal path folded into one route string.
+func serveMuxMethodReport(ctx *dsl.DoContext) {
+	method := strings.TrimPrefix(ctx.Var("method").Text(), "http.Method")
+	method = strings.TrimPrefix(strings.TrimSuffix(method, `"`), `"`)
+	// http.MethodGet -> "GET". Do callbacks have no strings.ToUpper, so
+	// upper-case one letter at a time.
+	lower := "abcdefghijklmnopqrstuvwxyz"
+	upper := "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	for lower != "" {
+		method = strings.ReplaceAll(method, lower[:1], upper[:1])
+		lower = lower[1:]
+		upper = upper[1:]
+	}
+	path := ctx.Var("path").Text()
+	route := `"` + method + ` "+` + path
+	if strings.HasPrefix(path, `"`) {
+		route = `"` + method + ` ` + path[1:]
+	}
+	ctx.SetReport("method guard in handler; register it as " + ctx.Var("mux").Text() + ".HandleFunc(" + route + ", ...) and drop the check (Go 1.22+)")
+}
+
+// ServeMuxPathValue detects IDs cut out of r.URL.Path with strings.TrimPrefix
+// and suggests Go 1.22 wildcard patterns with r.PathValue.
+//
+// The old pattern:
+//
+//	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+//	    id := strings.TrimPrefix(r.URL.Path, "/users/")
+//	    ...
+//	})
+//
+// New pattern (Go 1.22+):
+//
+//	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+//	    id := r.PathValue("id")
+//	    ...
+//	})
+//
+// Benefits:
+//   - "/users/1/extra" no longer reaches the handler with id "1/extra"
+//   - Wildcards are decoded and validated by ServeMux
+//
+// See: https://pkg.go.dev/net/http#Request.PathValue
+func ServeMuxPathValue(m dsl.Matcher) {
+	m.Match(
+		`$id := strings.TrimPrefix($r.URL.Path, $prefix)`,
+		`$id = strings.TrimPrefix($r.URL.Path, $prefix)`,
+	).
+		Where(m["r"].Type.Is("*http.Request") && m["prefix"].Const && m["id"].Text.Matches(`^\w+$`)).
+		Do(serveMuxPathValueReport)
+}
+
+// serveMuxPathValueReport builds the ServeMuxPathValue report with the
+// wildcard appended to a literal prefix.
+func serveMuxPathValueReport(ctx *dsl.DoContext) {
+	id := ctx.Var("id").Text()
+	prefix := ctx.Var("prefix").Text()
+	route := prefix + `+"{` + id + `}"`
+	if strings.HasPrefix(prefix, `"`) {
+		route = prefix[:len(prefix)-1] + `{` + id + `}"`
+	}
+	ctx.SetReport("path segment cut with strings.TrimPrefix; register the route as " + route + " and read it with " + ctx.Var("r").Text() + `.PathValue("` + id + `") (Go 1.22+)`)
+}
+
+// NetipAddr detects net.IP comparisons, map keys and CIDR checks that are
+// simpler and safer with net/netip.
+//
+// The old pattern:
+//
+//	if ip1.String() == ip2.String() { ... }
+//	if bytes.Equal(ip1, ip2) { ... }      // false for 4-byte vs 16-byte IPv4
+//	seen[ip.String()] = true
+//	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+//	if network.Contains(ip) { ... }
+//
+// New pattern:
+//
+//	addr1, _ := netip.ParseAddr(s1)
+//	if addr1 == addr2 { ... }             // or addr1.Compare(addr2) == 0
+//	seen[addr] = true                     // map[netip.Addr]bool
+//	prefix := netip.MustParsePrefix("10.0.0.0/8")
+//	if prefix.Contains(addr) { ... }
+//
+// Benefits:
+//   - netip.Addr is a comparable value type usable as a map key
+//   - No allocation per address, no ambiguity between IPv4 and IPv4-in-IPv6
+//
+// See: https://pkg.go.dev/net/netip
+func NetipAddr(m dsl.Matcher) {
+	// String comparison of net.IP values
+	m.Match(
+		`$a.String() == $b.String()`,
+		`$a.String() != $b.String()`,
+	).
+		Where(m["a"].Type.Is("net.IP") && m["b"].Type.Is("net.IP")).
+		Report("comparing net.IP via String() allocates and hides IPv4/IPv4-in-IPv6 differences; use netip.Addr and ==/Compare (or $a.Equal($b))")
+
+	// Byte comparison of net.IP values
+	m.Match(`bytes.Equal($a, $b)`).
+		Where(m["a"].Type.Is("net.IP") || m["b"].Type.Is("net.IP")).
+		Report("bytes.Equal on net.IP is false for the 4-byte and 16-byte forms of the same IPv4 address; use netip.Addr and == (or $a.Equal($b))")
+
+	// net.IP stored in maps through string conversion
+	m.Match(
+		`$m[$ip.String()]`,
+		`$m[string($ip)]`,
+	).
+		Where(m["ip"].Type.Is("net.IP")).
+		Report("net.IP used as a map key via string conversion; use map[netip.Addr] since netip.Addr is comparable")
+
+	// Hand-rolled CIDR checks
+	m.Match(`$n.Contains($ip)`).
+		Where(m["n"].Type.Is("*net.IPNet") && m["ip"].Type.Is("net.IP")).
+		Report("use netip.Prefix.Contains with netip.ParsePrefix instead of net.IPNet.Contains")
+
+	// Only when the network is used for membership checks; ParseCIDR is
+	// still the right call when the IP/Mask fields are needed.
+	m.Match(
+		`_, $n, $err := net.ParseCIDR($s); $*rest`,
+		`_, $n, $err = net.ParseCIDR($s); $*rest`,
+	).
+		Where(m["rest"].Contains(`$n.Contains($_)`)).
+		Report("use $n, $err := netip.ParsePrefix($s) and Prefix.Contains instead of net.ParseCIDR")
+}
+
+// NetParseIPValidity detects net.ParseIP(s) == nil used as a validity check
+// and suggests netip.ParseAddr.
+//
+// The old pattern:
+//
+//	if net.ParseIP(s) == nil {
+//	    return errors.New("invalid IP")
+//	}
+//
+// New pattern:
+//
+//	if _, err := netip.ParseAddr(s); err != nil {
+//	    return err
+//	}
+//
+// Benefits:
+//   - The error says what is wrong with the input
+//   - Zones ("fe80::1%eth0") are parsed instead of rejected
+//
+// See: https://pkg.go.dev/net/netip#ParseAddr
+func NetParseIPValidity(m dsl.Matcher) {
+	m.Match(
+		`net.ParseIP($s) == nil`,
+		`net.ParseIP($s) != nil`,
+		`nil == net.ParseIP($s)`,
+		`nil != net.ParseIP($s)`,
+	).
+		Report("use netip.ParseAddr($s) and check the error instead of comparing net.ParseIP($s) with nil")
+}
+
+// HTTPServerTimeouts detects HTTP servers started without read timeouts.
+//
+// The old pattern:
+//
+//	http.ListenAndServe(":8080", handler)
+//
+//	srv := &http.Server{Addr: ":8080", Handler: handler}
+//
+// Should be:
+//
+//	srv := &http.Server{
+//	    Addr:              ":8080",
+//	    Handler:           handler,
+//	    ReadHeaderTimeout: 10 * time.Second,
+//	    IdleTimeout:       120 * time.Second,
+//	}
+//	srv.ListenAndServe()
+//
+// Without ReadHeaderTimeout (or ReadTimeout) a client can hold a connection
+// open indefinitely by sending headers slowly (slowloris).
+//
+// Note: Server literals whose timeouts are assigned after construction
+// (srv.ReadHeaderTimeout = ...) are still flagged.
+//
+// See: https://pkg.go.dev/net/http#Server
+func HTTPServerTimeouts(m dsl.Matcher) {
+	m.Match(`http.ListenAndServe($addr, $h)`).
+		Report("http.ListenAndServe has no timeouts; use (&http.Server{Addr: $addr, Handler: $h, ReadHeaderTimeout: 10 * time.Second}).ListenAndServe()")
+
+	m.Match(`http.ListenAndServeTLS($addr, $cert, $key, $h)`).
+		Report("http.ListenAndServeTLS has no timeouts; use (&http.Server{Addr: $addr, Handler: $h, ReadHeaderTimeout: 10 * time.Second}).ListenAndServeTLS($cert, $key)")
+
+	m.Match(`http.Server{$*fields}`).
+		Where(!m["fields"].Contains(`ReadHeaderTimeout: $_`) && !m["fields"].Contains(`ReadTimeout: $_`)).
+		Report("http.Server without ReadHeaderTimeout or ReadTimeout is open to slowloris; set ReadHeaderTimeout: 10 * time.Second")
+}
+
+// HTTPClientTimeouts detects HTTP clients without a timeout in non-test code.
+//
+// The old pattern:
+//
+//	resp, err := http.Get(url)
+//	resp, err := http.DefaultClient.Do(req)
+//	client := &http.Client{}
+//
+// Should be:
+//
+//	client := &http.Client{Timeout: 30 * time.Second}
+//	resp, err := client.Get(url)
+//
+// http.DefaultClient has no timeout, so a stalled server blocks the caller
+// forever unless every request carries a context deadline.
+//
+// See: https://pkg.go.dev/net/http#Client
+func HTTPClientTimeouts(m dsl.Matcher) {
+	m.Match(
+		`http.Get($*_)`,
+		`http.Head($*_)`,
+		`http.Post($*_)`,
+		`http.PostForm($*_)`,
+	).
+		Where(!m.File().Name.Matches(`_test\.go$`)).
+		Report("package-level http helpers use http.DefaultClient, which has no timeout; use a client such as &http.Client{Timeout: 30 * time.Second}")
+
+	m.Match(`http.DefaultClient`).
+		Where(!m.File().Name.Matches(`_test\.go$`)).
+		Report("http.DefaultClient has no timeout; use a client such as &http.Client{Timeout: 30 * time.Second}")
+
+	m.Match(`http.Client{$*fields}`).
+		Where(!m.File().Name.Matches(`_test\.go$`) && !m["fields"].Contains(`Timeout: $_`)).
+		Report("http.Client without Timeout can hang forever; set Timeout: 30 * time.Second (or use per-request context deadlines)")
+}
+
+// CrossOriginProtection detects hand-rolled CSRF checks on the Origin,
+// Referer or Sec-Fetch-Site headers and suggests http.CrossOriginProtection.
+//
+// The old pattern:
+//
+//	origin := r.Header.Get("Origin")
+//	if origin != "" && origin != "https://"+r.Host {
+//	    http.Error(w, "cross-origin request", http.StatusForbidden)
+//	    return
+//	}
+//
+// New pattern (Go 1.25+):
+//
+//	cop := http.NewCrossOriginProtection()
+//	cop.AddTrustedOrigin("https://admin.example.com")
+//	handler = cop.Handler(handler)
+//
+// Benefits:
+//   - Uses Sec-Fetch-Site with an Origin fallback, as browsers intend
+//   - Safe methods (GET, HEAD, OPTIONS) pass through automatically
+//   - Allowlists live in one place via AddTrustedOrigin
+//
+// Comparisons against "" only test whether the header is present and are
+// not reported. Neither are ifs whose branches set
+// Access-Control-Allow-Origin: that is CORS code echoing an allowed Origin,
+// which CrossOriginProtection does not replace.
+//
+// See: https://pkg.go.dev/net/http#CrossOriginProtection
+func CrossOriginProtection(m dsl.Matcher) {
+	// Header compared directly in an if condition
+	m.Match(
+		`if $r.Header.Get($h) == $x { $*body }`,
+		`if $r.Header.Get($h) != $x { $*body }`,
+		`if strings.HasPrefix($r.Header.Get($h), $x) { $*body }`,
+		`if $x[$r.Header.Get($h)] { $*body }`,
+		`if slices.Contains($x, $r.Header.Get($h)) { $*body }`,
+	).
+		Where(
+			m["r"].Type.Is("*http.Request") && m["h"].Text.Matches(`(?i)^"(origin|referer|sec-fetch-site)"$`) && m["x"].Text != `""` &&
+				!m["body"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_["Access-Control-Allow-Origin"] = $_`),
+		).
+		At(m["r"]).
+		Report("hand-rolled cross-origin check on $h; wrap the handler with http.NewCrossOriginProtection().Handler (Go 1.25+) and use AddTrustedOrigin for allowlists")
+
+	m.Match(
+		`if $r.Header.Get($h) == $x { $*body } else { $*els }`,
+		`if $r.Header.Get($h) != $x { $*body } else { $*els }`,
+		`if strings.HasPrefix($r.Header.Get($h), $x) { $*body } else { $*els }`,
+		`if $x[$r.Header.Get($h)] { $*body } else { $*els }`,
+		`if slices.Contains($x, $r.Header.Get($h)) { $*body } else { $*els }`,
+	).
+		Where(
+			m["r"].Type.Is("*http.Request") && m["h"].Text.Matches(`(?i)^"(origin|referer|sec-fetch-site)"$`) && m["x"].Text != `""` &&
+				!m["body"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_["Access-Control-Allow-Origin"] = $_`) &&
+				!m["els"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["els"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["els"].Contains(`$_["Access-Control-Allow-Origin"] = $_`),
+		).
+		At(m["r"]).
+		Report("hand-rolled cross-origin check on $h; wrap the handler with http.NewCrossOriginProtection().Handler (Go 1.25+) and use AddTrustedOrigin for allowlists")
+
+	// Header compared inline elsewhere
+	m.Match(
+		`$r.Header.Get($h) == $x`,
+		`$r.Header.Get($h) != $x`,
+		`strings.HasPrefix($r.Header.Get($h), $x)`,
+		`$x[$r.Header.Get($h)]`,
+		`slices.Contains($x, $r.Header.Get($h))`,
+	).
+		Where(
+			m["r"].Type.Is("*http.Request") && m["h"].Text.Matches(`(?i)^"(origin|referer|sec-fetch-site)"$`) &&
+				m["x"].Text != `""` && !m["$$"].Node.Parent().Is("IfStmt"),
+		).
+		Report("hand-rolled cross-origin check on $h; wrap the handler with http.NewCrossOriginProtection().Handler (Go 1.25+) and use AddTrustedOrigin for allowlists")
+
+	// Header read into a variable and compared in the next if. The text
+	// check requires a comparison with something other than "".
+	m.Match(`$v := $r.Header.Get($h); if $cond { $*body }`).
+		Where(
+			m["r"].Type.Is("*http.Request") && m["h"].Text.Matches(`(?i)^"(origin|referer|sec-fetch-site)"$`) &&
+				(m["cond"].Contains(`$v == $_`) || m["cond"].Contains(`$v != $_`) ||
+					m["cond"].Contains(`strings.HasPrefix($v, $_)`) || m["cond"].Contains(`$_[$v]`) ||
+					m["cond"].Contains(`slices.Contains($_, $v)`)) &&
+				m["cond"].Text.Matches(`(==|!=)\s*([^"\s]|"[^"])|(HasPrefix|Contains)\(|\[`) &&
+				!m["body"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_["Access-Control-Allow-Origin"] = $_`),
+		).
+		Report("hand-rolled cross-origin check on $h; wrap the handler with http.NewCrossOriginProtection().Handler (Go 1.25+) and use AddTrustedOrigin for allowlists")
+
+	m.Match(`$v := $r.Header.Get($h); if $cond { $*body } else { $*els }`).
+		Where(
+			m["r"].Type.Is("*http.Request") && m["h"].Text.Matches(`(?i)^"(origin|referer|sec-fetch-site)"$`) &&
+				(m["cond"].Contains(`$v == $_`) || m["cond"].Contains(`$v != $_`) ||
+					m["cond"].Contains(`strings.HasPrefix($v, $_)`) || m["cond"].Contains(`$_[$v]`) ||
+					m["cond"].Contains(`slices.Contains($_, $v)`)) &&
+				m["cond"].Text.Matches(`(==|!=)\s*([^"\s]|"[^"])|(HasPrefix|Contains)\(|\[`) &&
+				!m["body"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["body"].Contains(`$_["Access-Control-Allow-Origin"] = $_`) &&
+				!m["els"].Contains(`$_.Set("Access-Control-Allow-Origin", $_)`) &&
+				!m["els"].Contains(`$_.Add("Access-Control-Allow-Origin", $_)`) &&
+				!m["els"].Contains(`$_["Access-Control-Allow-Origin"] = $_`),
+		).
+		Report("hand-rolled cross-origin check on $h; wrap the handler with http.NewCrossOriginProtection().Handler (Go 1.25+) and use AddTrustedOrigin for allowlists")
+}
+
+// ErrorBeforeUse detects a result being used before the error from the same
+// call is checked.
 //
 // Go 1.25 fixed a compiler bug (Go 1.21-1.24) where nil checks were incorrectly delayed.
 // Code that worked before may now correctly panic. This rule catches common patterns.
@@ -125,19 +594,31 @@ func DeprecatedReverseProxyDirector(m dsl.Matcher) {
 //	name := f.Name()  // PANICS if err != nil
 //	if err != nil { ... }
 //
+//	resp, err := http.Get(url)
+//	defer resp.Body.Close()  // PANICS if err != nil
+//	if err != nil { ... }
+//
 // Correct pattern:
 //
 //	f, err := os.Open(path)
 //	if err != nil { ... }
 //	name := f.Name()
 //
+// Any call returning (T, error) is covered when T is a pointer or interface
+// and the statements between the call and the error check read a field of
+// the result or call a method on it.
+//
 // See: https://go.dev/doc/go1.25#compiler (nil check reordering fix)
 func ErrorBeforeUse(m dsl.Matcher) {
-	// os.Open/Create followed by method call before error check
 	m.Match(
-		`$f, $err := os.Open($path); $_ := $f.$method($*_); if $err != nil { $*_ }`,
-		`$f, $err := os.Create($path); $_ := $f.$method($*_); if $err != nil { $*_ }`,
-		`$f, $err := os.OpenFile($*_); $_ := $f.$method($*_); if $err != nil { $*_ }`,
+		`$x, $err := $f($*_); $*mid; if $err != nil { $*_ }`,
+		`$x, $err = $f($*_); $*mid; if $err != nil { $*_ }`,
 	).
-		Report("potential nil pointer: $f may be nil if $err != nil; check error before using $f.$method()")
+		Where(
+			(m["x"].Type.Is("*$_") || m["x"].Type.Underlying().Is("interface{ $*_ }")) &&
+				m["err"].Type.Is("error") &&
+				(m["mid"].Contains(`$x.$_`) || m["mid"].Contains(`*$x`)) &&
+				!m["mid"].Contains(`$err`),
+		).
+		Report("potential nil pointer: $x may be nil if $err != nil; check $err before using $x")
 }
@@ -2,7 +2,8 @@
 #
 # Test runner for moderngo ruleguard rules.
 #
-# Runs golangci-lint on fixture files in testdata/ and verifies that:
+# Runs golangci-lint on fixture files in testdata/ (and each subdirectory with
+# its own .golangci.yml, such as the opt-in testdata/fips profile) and verifies that:
 #   1. Every line annotated with "// want: "fragment"" produces a diagnostic containing that fragment
 #   2. No unexpected diagnostics appear on unannotated lines
 #
@@ -44,71 +45,87 @@ log_fail() {
     echo -e "  ${RED}FAIL${RESET} $1"
 }
 
-# Step 1: Run golangci-lint on testdata/
-echo -e "${BOLD}Running golangci-lint on testdata/...${RESET}"
-cd "$TESTDATA_DIR"
+# check_dir runs golangci-lint on one fixture package and verifies its
+# annotations. Each directory uses the nearest .golangci.yml, so opt-in rule
+# profiles (e.g. testdata/fips) run with their own rules setting.
+check_dir() {
+    local dir="$1"
+    local label="${dir#"$SCRIPT_DIR"/}"
 
-lint_output=$(golangci-lint run --max-issues-per-linter=0 --max-same-issues=0 ./... 2>&1 || true)
+    # Step 1: Run golangci-lint on the fixture package
+    echo -e "${BOLD}Running golangci-lint on ${label}/...${RESET}"
+    cd "$dir" || return
 
-if [ "$VERBOSE" = "-v" ]; then
-    echo -e "${YELLOW}--- golangci-lint output ---${RESET}"
-    echo "$lint_output"
-    echo -e "${YELLOW}--- end output ---${RESET}"
-    echo
-fi
+    lint_output=$(golangci-lint run --max-issues-per-linter=0 --max-same-issues=0 . 2>&1 || true)
+
+    if [ "$VERBOSE" = "-v" ]; then
+        echo -e "${YELLOW}--- golangci-lint output ---${RESET}"
+        echo "$lint_output"
+        echo -e "${YELLOW}--- end output ---${RESET}"
+        echo
+    fi
+
+    # Step 2: Parse want annotations from the fixture files in this directory
+    echo -e "${BOLD}Checking expectations...${RESET}"
+
+    # Collect fixture files (both .go and _test.go)
+    fixture_files=$(find "$dir" -maxdepth 1 -name '*_check*.go' -type f | sort)
+
+    for fixture in $fixture_files; do
+        filename=$(basename "$fixture")
+        echo -e "\n${BOLD}$filename${RESET}"
 
-# Step 2: Parse want annotations from all fixture files
-echo -e "${BOLD}Checking expectations...${RESET}"
-
-# Collect all fixture files (both .go and _test.go)
-fixture_files=$(find "$TESTDATA_DIR" -name '*_check*.go' -type f | sort)
-
-for fixture in $fixture_files; do
-    filename=$(basename "$fixture")
-    echo -e "\n${BOLD}$filename${RESET}"
-
-    # Parse "// want: "fragment"" annotations
-    line_num=0
-    want_lines=()
-    while IFS= read -r line; do
-        line_num=$((line_num + 1))
-        # Match: // want: "some text"
-        if [[ "$line" =~ \/\/\ want:\ \"([^\"]+)\" ]]; then
-            fragment="${BASH_REMATCH[1]}"
-            want_lines+=("$line_num:$fragment")
-
-            # Check if golangci-lint reported a diagnostic on this line containing the fragment
-            # Output format: filename.go:LINE:COL: ruleguard: message (gocritic)
-            if echo "$lint_output" | grep -q "${filename}:${line_num}:.*${fragment}"; then
-                log_pass "line $line_num: found expected \"$fragment\""
-            else
-                log_fail "line $line_num: expected \"$fragment\" but no matching diagnostic"
+        # Parse "// want: "fragment"" annotations
+        line_num=0
+        want_lines=()
+        while IFS= read -r line; do
+            line_num=$((line_num + 1))
+            # Match: // want: "some text"
+            if [[ "$line" =~ \/\/\ want:\ \"([^\"]+)\" ]]; then
+                fragment="${BASH_REMATCH[1]}"
+                want_lines+=("$line_num:$fragment")
+
+                # Check if golangci-lint reported a diagnostic on this line containing the fragment
+                # Output format: filename.go:LINE:COL: ruleguard: message (gocritic)
+                if grep -q "${filename}:${line_num}:.*${fragment}" <<< "$lint_output"; then
+                    log_pass "line $line_num: found expected \"$fragment\""
+                else
+                    log_fail "$label/$filename line $line_num: expected \"$fragment\" but no matching diagnostic"
+                fi
             fi
-        fi
-    done < "$fixture"
-
-    # Step 3: Check for false positives — diagnostics on lines without want annotations
-    # Extract line numbers from golangci-lint output for this file
-    file_diags=$(echo "$lint_output" | grep "^${filename}:" || true)
-    while IFS= read -r diag_line; do
-        [ -z "$diag_line" ] && continue
-        # Parse "filename.go:LINE:COL: ..."
-        if [[ "$diag_line" =~ ^${filename}:([0-9]+): ]]; then
-            diag_line_num="${BASH_REMATCH[1]}"
-            # Check if this line has a want annotation
-            has_want=false
-            for want in "${want_lines[@]}"; do
-                want_num="${want%%:*}"
-                if [ "$diag_line_num" = "$want_num" ]; then
-                    has_want=true
-                    break
+        done < "$fixture"
+
+        # Step 3: Check for false positives — diagnostics on lines without want annotations
+        # Extract line numbers from golangci-lint output for this file
+        file_diags=$(echo "$lint_output" | grep "^${filename}:" || true)
+        while IFS= read -r diag_line; do
+            [ -z "$diag_line" ] && continue
+            # Parse "filename.go:LINE:COL: ..."
+            if [[ "$diag_line" =~ ^${filename}:([0-9]+): ]]; then
+                diag_line_num="${BASH_REMATCH[1]}"
+                # Check if this line has a want annotation
+                has_want=false
+                for want in "${want_lines[@]}"; do
+                    want_num="${want%%:*}"
+                    if [ "$diag_line_num" = "$want_num" ]; then
+                        has_want=true
+                        break
+                    fi
+                done
+                if [ "$has_want" = false ]; then
+                    log_fail "$label/$filename line $diag_line_num: unexpected diagnostic (false positive): $diag_line"
                 fi
-            done
-            if [ "$has_want" = false ]; then
-                log_fail "line $diag_line_num: unexpected diagnostic (false positive): $diag_line"
             fi
-        fi
-    done <<< "$file_diags"
+        done <<< "$file_diags"
+    done
+    echo
+}
+
+# Every directory under testdata/ with fixture files is a separate check.
+fixture_dirs=$(find "$TESTDATA_DIR" -name '*_check*.go' -type f -exec dirname {} \; | sort -u)
+
+for dir in $fixture_dirs; do
+    check_dir "$dir"
 done
 
 # Summary
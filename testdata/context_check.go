@@ -0,0 +1,369 @@
+package testdata
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// --- ContextCancelCause ---
+
+type watcher struct {
+	mu      sync.Mutex
+	stopErr error
+}
+
+func (w *watcher) checkContextCancelCause(parent context.Context, watch func() error) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	go func() {
+		if err := watch(); err != nil {
+			// Reported where it is read after Done, not here
+			w.stopErr = err
+			cancel()
+		}
+	}()
+
+	go func() {
+		if err := watch(); err != nil {
+			w.mu.Lock()
+			w.stopErr = err
+			w.mu.Unlock()
+			cancel()
+		}
+	}()
+
+	// Should trigger: side-channel error returned after Done
+	<-ctx.Done() // want: "return context.Cause(ctx)"
+	return w.stopErr
+}
+
+func checkContextErrAfterDone(ctx context.Context) error {
+	// Should trigger: ctx.Err() after Done
+	<-ctx.Done() // want: "prefer it to ctx.Err()"
+	return ctx.Err()
+}
+
+func checkContextCancelCauseShutdown(srv *http.Server, parent context.Context) error {
+	ctx, cancel := context.WithTimeout(parent, time.Second)
+	var err error
+
+	// Should NOT trigger: ordinary error handling next to cancel()
+	err = srv.Shutdown(ctx)
+	cancel()
+	return err
+}
+
+// Should trigger ContextWithoutCancel only: a fresh call after Done, not a stored reason
+func checkContextCancelCauseAfterDone(ctx context.Context, srv *http.Server) error { // want: "use context.WithoutCancel(ctx)"
+	<-ctx.Done()
+	return srv.Shutdown(context.Background())
+}
+
+func checkContextCancelCauseUnrelated(parent context.Context, srv *http.Server) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	// Should NOT trigger: err is never assigned next to cancel()
+	err := srv.Close()
+	<-ctx.Done()
+	return err
+}
+
+func checkContextCancelCauseNegative(parent context.Context) error {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	// Should NOT trigger: cause attached to the context
+	cancel(errors.New("shutting down"))
+
+	// Should NOT trigger: context.Cause after Done
+	<-ctx.Done()
+	return context.Cause(ctx)
+}
+
+// --- ContextAfterFunc ---
+
+type closer interface{ Close() error }
+
+func checkContextAfterFunc(ctx context.Context, conn closer, done chan struct{}) {
+	// Should trigger: goroutine parked on ctx.Done()
+	go func() { // want: "use stop := context.AfterFunc(ctx"
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	// Should trigger: single-case select
+	go func() { // want: "use stop := context.AfterFunc(ctx"
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		}
+	}()
+
+	// Should trigger: watcher with a stop channel
+	go func() { // want: "instead of closing the channel"
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+}
+
+func checkContextAfterFuncNegative(ctx context.Context, conn closer, work func()) {
+	// Should NOT trigger: AfterFunc already used
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	// Should NOT trigger: goroutine does work before waiting
+	go func() {
+		work()
+		<-ctx.Done()
+		conn.Close()
+	}()
+}
+
+// --- ContextWithoutCancel ---
+
+type detachedContext struct{ parent context.Context }
+
+// Should trigger: Value forwards to the wrapped parent
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (c detachedContext) Err() error { return nil }
+
+func (d detachedContext) Value(key any) any { return d.parent.Value(key) } // want: "detachedContext forwards Value to a wrapped context"
+
+// Should NOT trigger: Err returns nil but Value does not forward to a parent
+type emptyContext struct{}
+
+func (emptyContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (emptyContext) Done() <-chan struct{} { return nil }
+
+func (emptyContext) Err() error { return nil }
+
+func (emptyContext) Value(key any) any { return nil }
+
+// Should NOT trigger: not a context.Context
+type nilDone struct{}
+
+func (n nilDone) Done() <-chan struct{} { return nil }
+
+// Should trigger: ctx parameter in scope
+func checkContextWithoutCancel(ctx context.Context, audit func(context.Context)) { // want: "function that receives a context.Context"
+	go audit(context.Background())
+}
+
+// Should trigger: Background in an HTTP handler
+func checkContextWithoutCancelHandler(w http.ResponseWriter, r *http.Request) { // want: "use context.WithoutCancel(r.Context())"
+	go func() {
+		_ = context.Background()
+	}()
+}
+
+// Should NOT trigger: no context in scope
+func checkContextWithoutCancelNegative(audit func(context.Context)) {
+	go audit(context.Background())
+}
+
+// Should NOT trigger: WithoutCancel already used
+func checkContextWithoutCancelNegative2(ctx context.Context, audit func(context.Context)) {
+	go audit(context.WithoutCancel(ctx))
+}
+
+// --- ContextHTTPRequest ---
+
+// Should trigger: http.NewRequest with a ctx parameter in scope
+func checkContextHTTPRequest(ctx context.Context, url string) (*http.Request, error) { // want: "use http.NewRequestWithContext(ctx"
+	return http.NewRequest("GET", url, nil)
+}
+
+// Should NOT trigger: no context to pass
+func checkContextHTTPRequestNoCtx(url string) (*http.Request, error) {
+	return http.NewRequest("GET", url, nil)
+}
+
+// Should NOT trigger: context already passed
+func checkContextHTTPRequestNegative(ctx context.Context, url string) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, "GET", url, nil)
+}
+
+// --- ContextSQL ---
+
+// Should trigger: QueryRow on *sql.DB
+func checkContextSQL(ctx context.Context, db *sql.DB, id int) error { // want: "use QueryContext, QueryRowContext"
+	var name string
+	return db.QueryRow("SELECT name FROM users WHERE id = ?", id).Scan(&name)
+}
+
+// Should trigger: Exec on *sql.Tx in a method
+func (w *watcher) checkContextSQLMethod(ctx context.Context, tx *sql.Tx) error { // want: "use QueryContext, QueryRowContext"
+	_, err := tx.Exec("DELETE FROM sessions")
+	return err
+}
+
+type queryer interface {
+	Query(q string) ([]string, error)
+}
+
+func checkContextSQLNegative(ctx context.Context, db *sql.DB, r *http.Request) error {
+	// Should NOT trigger: ExecContext
+	_, err := db.ExecContext(ctx, "DELETE FROM sessions")
+
+	// Should NOT trigger: url.Values has no query argument
+	_ = r.URL.Query()
+	return err
+}
+
+// Should NOT trigger: no context to pass
+func checkContextSQLNoCtx(db *sql.DB, q queryer) error {
+	_, _ = q.Query("users")
+	_, err := db.Exec("DELETE FROM sessions")
+	return err
+}
+
+// --- ContextExecCommand ---
+
+// Should trigger: exec.Command with a ctx parameter in scope
+func checkContextExecCommand(ctx context.Context) error { // want: "use exec.CommandContext(ctx"
+	return exec.Command("go", "build", "./...").Run()
+}
+
+// Should trigger: func literal taking a ctx
+var buildWithCtx = func(ctx context.Context) error { // want: "use exec.CommandContext(ctx"
+	return exec.Command("go", "vet", "./...").Run()
+}
+
+// Should NOT trigger: no context to pass
+func checkContextExecCommandNoCtx() error {
+	return exec.Command("go", "build", "./...").Run()
+}
+
+// Should NOT trigger: CommandContext
+func checkContextExecCommandNegative(ctx context.Context) error {
+	return exec.CommandContext(ctx, "go", "build", "./...").Run()
+}
+
+// --- ContextNetDial ---
+
+// Should trigger: net.Dial
+func checkContextNetDial(ctx context.Context, addr string) (net.Conn, error) { // want: "DialContext(ctx"
+	return net.Dial("tcp", addr)
+}
+
+// Should trigger: package-level lookup
+func checkContextNetLookup(ctx context.Context, host string) ([]string, error) { // want: "net.DefaultResolver.LookupHost(ctx"
+	return net.LookupHost(host)
+}
+
+// Should NOT trigger: ctx only appears in a callback's signature
+func checkContextNetDialNoCtx(addr string, onConn func(context.Context, net.Conn)) error {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return err
+	}
+	onConn(context.TODO(), conn)
+	return nil
+}
+
+// Should NOT trigger: Dialer and Resolver with context
+func checkContextNetDialNegative(ctx context.Context, addr, host string) (net.Conn, error) {
+	if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// --- ContextValueKey ---
+
+type userIDKey struct{}
+
+func checkContextValueKey(ctx context.Context, id string) string {
+	// Should trigger: string key
+	ctx = context.WithValue(ctx, "userID", id) // want: "has a built-in type"
+
+	// Should trigger: int key
+	ctx = context.WithValue(ctx, 42, id) // want: "has a built-in type"
+
+	// Should trigger: exported key type from another package
+	ctx = context.WithValue(ctx, http.SameSiteStrictMode, id) // want: "has an exported named type"
+
+	// Should trigger: lookup with a string key
+	v, _ := ctx.Value("userID").(string) // want: "lookup with built-in key"
+	return v
+}
+
+type sessionKey string
+
+type keyConfig struct{ Key sessionKey }
+
+func checkContextValueKeyNegative(ctx context.Context, cfg keyConfig, id string) string {
+	// Should NOT trigger: unexported struct{} key
+	ctx = context.WithValue(ctx, userIDKey{}, id)
+
+	// Should NOT trigger: exported field holding an unexported key type
+	ctx = context.WithValue(ctx, cfg.Key, id)
+	v, _ := ctx.Value(userIDKey{}).(string)
+	return v
+}
+
+// --- SignalNotifyContext ---
+
+func checkSignalNotifyContext(cancel context.CancelFunc) {
+	sigCh := make(chan os.Signal, 1)
+	// Should trigger: goroutine cancels on signal
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM) // want: "signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)"
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+}
+
+func checkSignalNotifyContextBlocking() {
+	sigCh := make(chan os.Signal, 1)
+	// Should trigger: main blocks on the channel
+	signal.Notify(sigCh, os.Interrupt) // want: "wait on <-ctx.Done()"
+	<-sigCh
+}
+
+func checkSignalNotifyContextNegative(ctx context.Context) {
+	// Should NOT trigger: NotifyContext already used
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	<-ctx.Done()
+}
+
+// --- SignalNotifyUnbuffered ---
+
+func checkSignalNotifyUnbuffered(handle func(os.Signal)) {
+	// Should trigger: unbuffered signal channel
+	sigCh := make(chan os.Signal) // want: "use make(chan os.Signal, 1)"
+	signal.Notify(sigCh, syscall.SIGHUP)
+	for sig := range sigCh {
+		handle(sig)
+	}
+}
+
+func checkSignalNotifyUnbufferedNegative(handle func(os.Signal)) {
+	// Should NOT trigger: buffered channel
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	for sig := range sigCh {
+		handle(sig)
+	}
+}
@@ -1,10 +1,31 @@
 package testdata
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/cipher"
+	"crypto/dsa"
+	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/md5"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
 )
 
 // --- DeprecatedPKCS1v15 ---
@@ -31,8 +52,44 @@ func checkWeakRSA() {
 	// Should trigger: 768-bit key (too small)
 	_, _ = rsa.GenerateKey(rand.Reader, 768) // want: "RSA keys smaller than 1024"
 
+	// Should trigger: named constant below 2048 bits
+	const keyBits = 1024
+	_, _ = rsa.GenerateKey(rand.Reader, keyBits) // want: "RSA 1024-bit keys are considered weak"
+
+	// Should trigger: literal between 1024 and 2048
+	_, _ = rsa.GenerateKey(rand.Reader, 1536) // want: "RSA key size 1536 is below 2048 bits"
+
+	// Should trigger: constant expression below 2048
+	const halfBits = 2048 / 2
+	_, _ = rsa.GenerateKey(rand.Reader, halfBits+512) // want: "is below 2048 bits"
+
 	// Should NOT trigger: adequate key size
 	_, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+	// Should NOT trigger: adequate named constant
+	const strongBits = 4096
+	_, _ = rsa.GenerateKey(rand.Reader, strongBits)
+}
+
+func checkWeakRSARuntimeSize(bits int) {
+	// Should NOT trigger: size only known at run time
+	_, _ = rsa.GenerateKey(rand.Reader, bits)
+}
+
+// --- WeakAsymmetricKeys ---
+
+func checkWeakAsymmetricKeys() {
+	// Should trigger: deprecated DSA
+	var params dsa.Parameters
+	_ = dsa.GenerateParameters(&params, rand.Reader, dsa.L1024N160) // want: "crypto/dsa is deprecated"
+	priv := dsa.PrivateKey{PublicKey: dsa.PublicKey{Parameters: params}}
+	_ = dsa.GenerateKey(&priv, rand.Reader) // want: "crypto/dsa is deprecated"
+
+	// Should trigger: P-224 curve
+	_, _ = ecdsa.GenerateKey(elliptic.P224(), rand.Reader) // want: "P-224 provides only 112-bit security"
+
+	// Should NOT trigger: P-256 curve
+	_, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 }
 
 // --- DeprecatedCipherModes ---
@@ -63,13 +120,311 @@ func checkElliptic() {
 	data := []byte{0x04}
 	_, _ = elliptic.Unmarshal(elliptic.P256(), data) // want: "elliptic.Unmarshal is deprecated"
 
+	// Should trigger: named curve with a direct ecdh replacement
+	_, _ = elliptic.Unmarshal(elliptic.P384(), data) // want: "use ecdh.P384().NewPublicKey(data)"
+
+	// Should trigger: deprecated compressed encoding
+	_ = elliptic.MarshalCompressed(elliptic.P256(), x, y)      // want: "elliptic.MarshalCompressed is a low-level API"
+	_, _ = elliptic.UnmarshalCompressed(elliptic.P256(), data) // want: "elliptic.UnmarshalCompressed is a low-level API"
+
 	// Should NOT trigger: getting a curve (not deprecated by our rules)
 	_ = elliptic.P256()
 }
 
+func checkEllipticECDSA(pub *ecdsa.PublicKey, priv *ecdsa.PrivateKey, hash []byte) {
+	// Should trigger: Marshal of ecdsa.PublicKey coordinates
+	_ = elliptic.Marshal(pub.Curve, pub.X, pub.Y) // want: "use pub.ECDH()"
+
+	// Should trigger: low-level Curve methods
+	curve := elliptic.P256()
+	_, _ = curve.ScalarMult(pub.X, pub.Y, hash)  // want: "elliptic.Curve.ScalarMult is deprecated"
+	_, _ = curve.ScalarBaseMult(hash)            // want: "elliptic.Curve.ScalarBaseMult is deprecated"
+	_ = curve.IsOnCurve(pub.X, pub.Y)            // want: "elliptic.Curve.IsOnCurve is deprecated"
+	_, _ = curve.Add(pub.X, pub.Y, pub.X, pub.Y) // want: "point arithmetic is deprecated"
+	_, _ = curve.Double(pub.X, pub.Y)            // want: "point arithmetic is deprecated"
+
+	// Should trigger: Curve methods promoted through ecdsa.PublicKey
+	_ = pub.IsOnCurve(pub.X, pub.Y) // want: "elliptic.Curve.IsOnCurve is deprecated"
+
+	// Should trigger: raw r, s signing and verification
+	r, s, err := ecdsa.Sign(rand.Reader, priv, hash) // want: "ecdsa.Sign is a low-level API"
+	_ = err
+	_ = ecdsa.Verify(pub, hash, r, s) // want: "ecdsa.Verify is a low-level API"
+
+	// Should NOT trigger: big.Int arithmetic and ASN.1 signatures
+	_ = new(big.Int).Add(pub.X, pub.Y)
+	sig, _ := ecdsa.SignASN1(rand.Reader, priv, hash)
+	_ = ecdsa.VerifyASN1(pub, hash, sig)
+}
+
+type ecdsaSignature struct{ R, S *big.Int }
+
+func checkECDSASignASN1Fix(priv *ecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	// Should trigger: r, s only used for ASN.1 encoding (autofix to SignASN1)
+	r, s, err := ecdsa.Sign(rand.Reader, priv, hash) // want: "ecdsa.Sign is a low-level API"
+	if err != nil {
+		return nil, err
+	}
+	sig, err := asn1.Marshal(ecdsaSignature{r, s})
+	return sig, err
+}
+
+func checkECDSAVerifyASN1Fix(pub *ecdsa.PublicKey, hash, der []byte) bool {
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(der, &sig); err != nil { // want: "prefer ecdsa.VerifyASN1(pub, hash, der)"
+		return false
+	}
+	return ecdsa.Verify(pub, hash, sig.R, sig.S) // want: "ecdsa.Verify is a low-level API"
+}
+
 // --- DeprecatedRSAMultiPrime ---
 
 func checkMultiPrime() {
 	// Should trigger: deprecated GenerateMultiPrimeKey
 	_, _ = rsa.GenerateMultiPrimeKey(rand.Reader, 3, 2048) // want: "rsa.GenerateMultiPrimeKey is deprecated"
 }
+
+// --- ConstantTimeCompare ---
+
+func checkConstantTimeCompare(key, msg, expectedMAC []byte, sig, csrfToken, storedToken, name string) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+
+	// Should trigger: bytes.Equal on hash.Hash.Sum output
+	_ = bytes.Equal(mac.Sum(nil), expectedMAC) // want: "bytes.Equal on a MAC or hash is not constant-time"
+	_ = bytes.Equal(expectedMAC, mac.Sum(nil)) // want: "bytes.Equal on a MAC or hash is not constant-time"
+
+	// Should trigger: bytes.Equal on a variable named like a MAC
+	computed := mac.Sum(nil)
+	_ = bytes.Equal(computed, expectedMAC) // want: "is not constant-time and leaks timing information for MACs"
+
+	// Should trigger: == on digests and hex-encoded digests
+	expectedSum := sha256.Sum256(key)
+	_ = sha256.Sum256(msg) == expectedSum                 // want: "comparing a digest with == or != is not constant-time"
+	_ = hex.EncodeToString(mac.Sum(nil)) == sig           // want: "comparing a hex-encoded digest"
+	_ = hex.EncodeToString(expectedSum[:]) != string(msg) // want: "comparing a hex-encoded digest"
+	_ = csrfToken == storedToken                          // want: "is not constant-time and leaks timing information for MACs"
+	_ = strings.EqualFold(csrfToken, storedToken)         // want: "strings.EqualFold(csrfToken, storedToken) is not constant-time"
+	_ = strings.EqualFold(hex.EncodeToString(msg), sig)   // want: "strings.EqualFold on a hex-encoded digest"
+
+	// Should trigger: hex string compared with raw digest (lengths never match)
+	_ = subtle.ConstantTimeCompare([]byte(hex.EncodeToString(msg)), mac.Sum(nil)) // want: "always fails because the lengths differ"
+	_ = hmac.Equal(mac.Sum(nil), []byte(hex.EncodeToString(msg)))                 // want: "always fails because the lengths differ"
+
+	// Should NOT trigger: constant-time comparisons
+	_ = hmac.Equal(mac.Sum(nil), expectedMAC)
+	_ = subtle.ConstantTimeCompare([]byte(csrfToken), []byte(storedToken)) == 1
+
+	// Should NOT trigger: comparison against a constant
+	_ = sig == ""
+	_ = hex.EncodeToString(msg) == "deadbeef"
+
+	// Should NOT trigger: hex of arbitrary bytes, not a digest
+	_ = hex.EncodeToString(msg) == name
+
+	// Should NOT trigger: unrelated names
+	_ = bytes.Equal(key, msg)
+	_ = strings.EqualFold(name, "admin")
+}
+
+// --- AEADNonceMisuse ---
+
+var globalNonce = make([]byte, 12)
+
+func deriveNonce(msg []byte) []byte { return msg[:12] }
+
+func checkAEADNonceMisuse(aead cipher.AEAD, plaintext []byte, msgs [][]byte) [][]byte {
+	// Should trigger: constant nonce
+	_ = aead.Seal(nil, []byte("fixednonce12"), plaintext, nil) // want: "constant nonce passed to aead.Seal"
+	_ = aead.Seal(nil, []byte{1, 2, 3}, plaintext, nil)        // want: "constant nonce passed to aead.Seal"
+
+	// Should trigger: zero-filled nonce
+	_ = aead.Seal(nil, make([]byte, 12), plaintext, nil) // want: "zero-filled nonce passed to aead.Seal"
+
+	// Should trigger: package-level nonce
+	_ = aead.Seal(nil, globalNonce, plaintext, nil) // want: "package-level nonce globalNonce"
+
+	// Should trigger: nonce allocated but never filled
+	zeroNonce := make([]byte, aead.NonceSize()) // want: "is never filled before aead.Seal"
+	sealed := aead.Seal(nil, zeroNonce, plaintext, nil)
+	_ = sealed
+
+	// Should trigger: nonce reused across loop iterations (GCMWithRandomNonce also fires on the allocation)
+	nonce := make([]byte, aead.NonceSize()) // want: "use cipher.NewGCMWithRandomNonce"
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil
+	}
+	var out [][]byte
+	for _, msg := range msgs { // want: "nonce is reused across loop iterations"
+		out = append(out, aead.Seal(nil, nonce, msg, nil))
+	}
+
+	// Should NOT trigger reuse: fresh random nonce per iteration (GCMWithRandomNonce still applies)
+	for _, msg := range msgs {
+		fresh := make([]byte, aead.NonceSize()) // want: "use cipher.NewGCMWithRandomNonce"
+		if _, err := io.ReadFull(rand.Reader, fresh); err != nil {
+			return nil
+		}
+		out = append(out, aead.Seal(fresh, fresh, msg, nil))
+	}
+
+	// Should NOT trigger reuse: nonce reassigned in the loop
+	for _, msg := range msgs {
+		nonce = deriveNonce(msg)
+		out = append(out, aead.Seal(nil, nonce, msg, nil))
+	}
+
+	// Should NOT trigger: 12- and 24-byte nonces
+	nonce12 := make([]byte, 12)
+	nonce24 := make([]byte, 24)
+	_, _ = nonce12, nonce24
+
+	// Should NOT trigger: 32-byte OAuth nonce that never reaches an AEAD
+	oauthNonce := make([]byte, 32)
+	_, _ = io.ReadFull(rand.Reader, oauthNonce)
+
+	return out
+}
+
+func checkAEADNonceSize(aead cipher.AEAD, plaintext []byte) []byte {
+	// Should trigger: hard-coded nonce size that GCM rejects
+	shortNonce := make([]byte, 8) // want: "shortNonce is 8 bytes"
+	_, _ = io.ReadFull(rand.Reader, shortNonce)
+	return aead.Seal(nil, shortNonce, plaintext, nil)
+}
+
+// --- GCMWithRandomNonce ---
+
+func checkGCMWithRandomNonce(aead cipher.AEAD, plaintext []byte) []byte {
+	// Should trigger: hand-rolled random nonce
+	nonce := make([]byte, aead.NonceSize()) // want: "use cipher.NewGCMWithRandomNonce"
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil)
+}
+
+// --- WeakHashSecurity ---
+
+func checkWeakHashSecurity(priv *rsa.PrivateKey, key, digest, body, seed []byte, password string) {
+	// Should trigger: HMAC-MD5 and MD5-derived HMAC key
+	_ = hmac.New(md5.New, key)                   // want: "HMAC-MD5 is not an approved MAC"
+	_ = hmac.New(sha256.New, md5.New().Sum(key)) // want: "HMAC key derived from MD5 or SHA-1"
+
+	// Should trigger: signatures over MD5/SHA-1
+	_, _ = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA1, digest) // want: "signature over an MD5 or SHA-1 digest"
+	_, _ = rsa.SignPSS(rand.Reader, priv, crypto.MD5, digest, nil)  // want: "signature over an MD5 or SHA-1 digest"
+
+	// Should trigger: password hashed with MD5/SHA-1
+	_ = sha1.Sum([]byte(password)) // want: "MD5 and SHA-1 are not suitable for passwords"
+
+	// Should trigger: result stored as a token or signature
+	token := fmt.Sprintf("%x", md5.Sum(seed)) // want: "token should be derived with SHA-256"
+	reqSig := sha1.Sum(body)                  // want: "reqSig should be derived with SHA-256"
+	_, _ = token, reqSig
+
+	// Should NOT trigger: ETag and cache key (non-security use)
+	etag := fmt.Sprintf("%x", md5.Sum(body))
+	cacheKey := sha1.Sum(body)
+	_, _ = etag, cacheKey
+
+	// Should NOT trigger: "designated" contains "sig" but is not a signature
+	designated := md5.Sum(body)
+	_ = designated
+
+	// Should NOT trigger: HMAC-SHA256 and SHA-256 signatures
+	_ = hmac.New(sha256.New, key)
+	_, _ = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+}
+
+// --- PasswordHashing ---
+
+func checkPasswordHashing(password string, salt, body []byte) {
+	// Should trigger: password hashed with plain SHA-2
+	_ = sha256.Sum256([]byte(password))                  // want: "password hashed with a fast SHA-2 digest"
+	_ = sha512.Sum512(append(salt, []byte(password)...)) // want: "password hashed with a fast SHA-2 digest"
+
+	// Should trigger: password written into a hash.Hash
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(password)) // want: "password hashed with a fast general-purpose hash"
+	_ = h.Sum(nil)
+
+	// Should NOT trigger: non-password input
+	_ = sha256.Sum256(body)
+	h.Write(body)
+}
+
+// --- TLS configuration ---
+
+func checkTLSConfig(certs []tls.Certificate) {
+	// Should trigger: InsecureSkipVerify outside tests
+	_ = &tls.Config{InsecureSkipVerify: true} // want: "InsecureSkipVerify disables certificate and hostname verification"
+	cfg := &tls.Config{}
+	cfg.InsecureSkipVerify = true // want: "InsecureSkipVerify disables certificate and hostname verification"
+
+	// Should trigger: MinVersion below TLS 1.2
+	_ = &tls.Config{MinVersion: tls.VersionTLS10} // want: "enables TLS 1.0/1.1"
+	cfg.MinVersion = tls.VersionTLS11             // want: "enables TLS 1.0/1.1"
+
+	// Should trigger: insecure cipher suites
+	_ = &tls.Config{
+		CipherSuites: []uint16{ // want: "includes suites from tls.InsecureCipherSuites"
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_RSA_WITH_RC4_128_SHA,
+		},
+	}
+	cfg.CipherSuites = []uint16{tls.TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA} // want: "includes suites from tls.InsecureCipherSuites"
+
+	// Should trigger: deprecated fields and methods
+	_ = &tls.Config{PreferServerCipherSuites: true} // want: "PreferServerCipherSuites is deprecated"
+	cfg.PreferServerCipherSuites = true             // want: "PreferServerCipherSuites is deprecated"
+	cfg.NameToCertificate = nil                     // want: "NameToCertificate is deprecated"
+	cfg.BuildNameToCertificate()                    // want: "BuildNameToCertificate is deprecated"
+
+	// Should trigger: every problem in one literal is reported
+	_ = &tls.Config{
+		InsecureSkipVerify:       true,                                   // want: "InsecureSkipVerify disables certificate and hostname verification"
+		MinVersion:               tls.VersionTLS10,                       // want: "enables TLS 1.0/1.1"
+		CipherSuites:             []uint16{tls.TLS_RSA_WITH_RC4_128_SHA}, // want: "includes suites from tls.InsecureCipherSuites"
+		PreferServerCipherSuites: true,                                   // want: "PreferServerCipherSuites is deprecated"
+	}
+
+	// Should NOT trigger: hardened configuration
+	_ = &tls.Config{
+		Certificates: certs,
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+	}
+	cfg.MinVersion = tls.VersionTLS13
+	cfg.InsecureSkipVerify = false
+}
+
+// --- DeprecatedPEMEncryption ---
+
+func checkPEMEncryption(block *pem.Block, password, der []byte) {
+	// Should trigger: legacy PEM encryption
+	_ = x509.IsEncryptedPEMBlock(block)                                                              // want: "x509.IsEncryptedPEMBlock is deprecated"
+	_, _ = x509.DecryptPEMBlock(block, password)                                                     // want: "x509.DecryptPEMBlock is deprecated"
+	_, _ = x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", der, password, x509.PEMCipherAES256) // want: "x509.EncryptPEMBlock is deprecated"
+
+	// Should NOT trigger: PKCS#8 parsing
+	_, _ = x509.ParsePKCS8PrivateKey(der)
+}
+
+// --- DeprecatedCRL ---
+
+func checkCRL(caCert *x509.Certificate, caKey *rsa.PrivateKey, data, der []byte, revoked []pkix.RevokedCertificate) {
+	// Should trigger: deprecated CRL functions
+	_, _ = x509.ParseCRL(data)   // want: "x509.ParseCRL is deprecated"
+	_, _ = x509.ParseDERCRL(der) // want: "x509.ParseDERCRL is deprecated"
+	now := time.Now()
+	_, _ = caCert.CreateCRL(rand.Reader, caKey, revoked, now, now.Add(24*time.Hour)) // want: "CreateCRL is deprecated"
+
+	// Should NOT trigger: RevocationList API
+	_, _ = x509.ParseRevocationList(der)
+	_, _ = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{ThisUpdate: now}, caCert, caKey)
+}
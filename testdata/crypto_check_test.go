@@ -0,0 +1,15 @@
+package testdata
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+// --- TLSInsecureSkipVerify ---
+
+func TestTLSInsecureSkipVerify(t *testing.T) {
+	// Should NOT trigger: InsecureSkipVerify is acceptable in tests
+	cfg := &tls.Config{InsecureSkipVerify: true}
+	cfg.InsecureSkipVerify = true
+	_ = cfg
+}
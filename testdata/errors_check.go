@@ -1,8 +1,15 @@
 package testdata
 
 import (
+	"bufio"
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"io"
 	"io/fs"
+	"os"
+	"strings"
 )
 
 func checkErrorsAsType(err error) {
@@ -21,3 +28,212 @@ func checkErrorsAsType(err error) {
 	var target error
 	_ = errors.As(err, &target) // want: "use errors.AsType"
 }
+
+// --- ErrorsJoin ---
+
+func checkErrorsJoin(errs []error, err1, err2 error, name string) error {
+	// Should trigger: []error flattened into strings
+	var msgs []string
+	for _, e := range errs { // want: "use errors.Join(errs...)"
+		msgs = append(msgs, e.Error())
+	}
+	_ = errors.New(strings.Join(msgs, "; "))
+
+	// Should trigger: two errors formatted with %v
+	_ = fmt.Errorf("%v; %v", err1, err2)               // want: "use errors.Join(err1, err2)"
+	_ = fmt.Errorf("write: %s, close: %s", err1, err2) // want: "use errors.Join(err1, err2)"
+
+	// Should NOT trigger: already wraps both errors
+	_ = fmt.Errorf("write: %w, close: %w", err1, err2)
+
+	// Should NOT trigger ErrorsJoin: only one argument is an error (ErrorfWrapVerb applies)
+	_ = fmt.Errorf("%s: %v", name, err1) // want: "use %w instead of %v or %s to wrap err1"
+
+	// Should NOT trigger: non-error strings collected
+	var names []string
+	for _, n := range msgs {
+		names = append(names, strings.ToUpper(n))
+	}
+	_ = names
+
+	// NOTE: hashicorp/go-multierror and go.uber.org/multierr are not
+	// dependencies of testdata, so their patterns have no fixtures here.
+	return errors.Join(errs...)
+}
+
+// --- ErrorsIsComparison ---
+
+var ErrNotSupported = errors.New("not supported") // want: "duplicates errors.ErrUnsupported"
+
+func loadConfig() ([]byte, error) { return nil, io.EOF }
+
+func checkErrorsIsComparison(err error, r io.Reader, br *bufio.Reader) {
+	// Should trigger: sentinel compared with == / !=
+	if err == sql.ErrNoRows { // want: "use errors.Is(err, sql.ErrNoRows)"
+		return
+	}
+	if err != fs.ErrNotExist { // want: "use !errors.Is(err, fs.ErrNotExist)"
+		return
+	}
+	_ = err == ErrNotSupported // want: "use errors.Is(err, ErrNotSupported)"
+
+	// Should trigger: switch on error value
+	switch err { // want: "switch on err compares with =="
+	case os.ErrPermission:
+	case os.ErrExist:
+	}
+
+	// Should NOT trigger: only a nil case
+	switch err {
+	case nil:
+		return
+	}
+
+	// Should trigger: io.EOF from a function that may wrap it
+	_, cfgErr := loadConfig() // want: "loadConfig is not an io.Reader method"
+	if cfgErr == io.EOF {
+		return
+	}
+
+	// Should NOT trigger: io.EOF returned directly from Read
+	buf := make([]byte, 8)
+	_, readErr := r.Read(buf)
+	if readErr == io.EOF {
+		return
+	}
+	_, lineErr := br.ReadString('\n')
+	if lineErr == io.EOF {
+		return
+	}
+
+	// Should NOT trigger: nil checks and errors.Is
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string { return "not found" }
+
+// Should NOT trigger: custom Is method compares target directly
+func (notFoundError) Is(target error) bool { return target == fs.ErrNotExist }
+
+// --- ErrorTypeAssertion ---
+
+type timeouter interface{ Timeout() bool }
+
+func checkErrorTypeAssertion(err error) {
+	// Should trigger: two-value type assertion on error
+	if pathErr, ok := err.(*fs.PathError); ok { // want: "use errors.AsType"
+		_ = pathErr
+	}
+	var nf notFoundError
+	var ok bool
+	nf, ok = err.(notFoundError) // want: "use errors.AsType"
+	_, _ = nf, ok
+
+	// Should NOT trigger: unpacking errors.Join results, as ErrorsJoin suggests
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		_ = joined.Unwrap()
+	}
+
+	// Should NOT trigger: target does not implement error
+	if te, ok := err.(timeouter); ok {
+		_ = te.Timeout()
+	}
+
+	// Should trigger: type switch on error
+	switch e := err.(type) { // want: "type switch on err only inspects the outermost error"
+	case *fs.PathError:
+		_ = e
+	}
+
+	// Should NOT trigger: type assertion on a non-error interface
+	var v any = err
+	if s, ok := v.(fmt.Stringer); ok {
+		_ = s
+	}
+}
+
+// --- ErrorfWrapVerb ---
+
+func checkErrorfWrapVerb(err, closeErr error, id int, name string) {
+	// Should trigger: error formatted with %v or %s
+	_ = fmt.Errorf("open config: %v", err)                // want: "use %w instead of %v or %s to wrap err"
+	_ = fmt.Errorf("load %q: %s", name, err)              // want: "use %w instead of %v or %s to wrap err"
+	_ = fmt.Errorf("write: %w, close: %v", err, closeErr) // want: "multiple %w are allowed since Go 1.20"
+
+	// Should trigger: %w on a non-error
+	_ = fmt.Errorf("bad id: %w", id)          // want: "id is not an error"
+	_ = fmt.Errorf("user %w: %v", name, err)  // want: "name is not an error"
+	_ = fmt.Errorf("user %s: %w", name, name) // want: "name is not an error"
+
+	// Should trigger: constant message
+	_ = fmt.Errorf("not found") // want: "use errors.New("
+
+	// Should NOT trigger: correct wrapping
+	_ = fmt.Errorf("open config: %w", err)
+	_ = fmt.Errorf("write: %w, close: %w", err, closeErr)
+	_ = fmt.Errorf("bad id: %d", id)
+
+	// Should NOT trigger: escaped percent sign
+	_ = fmt.Errorf("100%% done")
+}
+
+// --- ErrorStringMatching ---
+
+func checkErrorStringMatching(err error, msg string) {
+	// Should trigger: well-known messages with a sentinel
+	_ = strings.Contains(err.Error(), "use of closed network connection") // want: "use errors.Is(err, net.ErrClosed)"
+	_ = err.Error() == "EOF"                                              // want: "use errors.Is(err, io.EOF)"
+	_ = "unexpected EOF" == err.Error()                                   // want: "use errors.Is(err, io.ErrUnexpectedEOF)"
+	_ = strings.HasPrefix(err.Error(), "context deadline")                // want: "use errors.Is(err, context.DeadlineExceeded)"
+	_ = strings.HasSuffix(err.Error(), "i/o timeout")                     // want: "use errors.Is(err, os.ErrDeadlineExceeded)"
+	_ = err.Error() != "http: Server closed"                              // want: "use errors.Is(err, http.ErrServerClosed)"
+	_ = strings.Contains(err.Error(), "no such file or directory")        // want: "use errors.Is(err, fs.ErrNotExist)"
+
+	// Should trigger: unknown message
+	_ = strings.Contains(err.Error(), "quota exceeded") // want: "matching err.Error() text is fragile"
+
+	// Should NOT trigger: errors.Is and non-error strings
+	_ = errors.Is(err, context.Canceled)
+	_ = strings.Contains(msg, "EOF")
+	_ = msg == "EOF"
+}
+
+// --- StdlibSentinels ---
+
+var (
+	ErrUnsupportedOp   = errors.New("unsupported operation")            // want: "duplicates errors.ErrUnsupported"
+	ErrFileClosed      = errors.New("file already closed")              // want: "duplicates fs.ErrClosed"
+	ErrAlreadyFinished = errors.New("process already finished")         // want: "duplicates os.ErrProcessDone"
+	ErrShort           = errors.New("short write")                      // want: "duplicates io.ErrShortWrite"
+	ErrNoFile          = errors.New("file does not exist")              // want: "duplicates fs.ErrNotExist"
+	ErrDenied          = errors.New("Permission denied")                // want: "duplicates fs.ErrPermission"
+	ErrConnClosed      = errors.New("use of closed network connection") // want: "duplicates net.ErrClosed"
+	ErrTruncated       = errors.New("unexpected EOF")                   // want: "duplicates io.ErrUnexpectedEOF"
+
+	// Should NOT trigger: alias and wrap of the standard sentinel
+	ErrNoSupport    = errors.ErrUnsupported
+	ErrDriverNoSupp = fmt.Errorf("mydriver: %w", errors.ErrUnsupported)
+
+	// Should NOT trigger: domain-specific message
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+var ErrTypedClosed error = errors.New("file already closed") // want: "duplicates fs.ErrClosed"
+
+func checkStdlibSentinelsNegative(n int) error {
+	// Should NOT trigger: function-local variable, not a package-level sentinel
+	var errNoSupport = errors.New("not supported")
+	if n > 100 {
+		return errNoSupport
+	}
+
+	// Should NOT trigger: not a sentinel declaration
+	if n < 0 {
+		return errors.New("invalid argument")
+	}
+	return nil
+}
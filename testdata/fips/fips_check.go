@@ -0,0 +1,105 @@
+package fips
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/des"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+)
+
+// --- FIPSApprovedHashes ---
+
+func checkFIPSHashes(priv *rsa.PrivateKey, data, digest []byte) {
+	// Should trigger: MD5 is never approved
+	_ = md5.New()     // want: "FIPS 140-3: MD5 is not an approved hash function"
+	_ = md5.Sum(data) // want: "FIPS 140-3: MD5 is not an approved hash function"
+
+	// Should trigger: SHA-1 signatures (WeakHashSecurity reports the call,
+	// the FIPS rule reports the statement)
+	_, _ = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA1, digest) // want: "FIPS 140-3: SHA-1 is disallowed for signature generation"
+	_ = &x509.Certificate{SignatureAlgorithm: x509.SHA1WithRSA}     // want: "FIPS 140-3: x509.SHA1WithRSA signs with MD5 or SHA-1"
+	_ = &x509.Certificate{SignatureAlgorithm: x509.ECDSAWithSHA1}   // want: "FIPS 140-3: x509.ECDSAWithSHA1 signs with MD5 or SHA-1"
+
+	// Should NOT trigger: SHA-256
+	_ = sha256.Sum256(data)
+	_, _ = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+	_ = &x509.Certificate{SignatureAlgorithm: x509.SHA256WithRSA}
+}
+
+// --- FIPSApprovedCiphers ---
+
+func checkFIPSCiphers(pub *rsa.PublicKey, key, msg []byte) {
+	// Should trigger: RC4, DES and 3DES
+	_, _ = rc4.NewCipher(key)          // want: "FIPS 140-3: RC4 is not an approved cipher"
+	_, _ = des.NewCipher(key)          // want: "FIPS 140-3: DES is not an approved cipher"
+	_, _ = des.NewTripleDESCipher(key) // want: "FIPS 140-3: Triple DES encryption was withdrawn"
+
+	// Should trigger: PKCS#1 v1.5 encryption (DeprecatedPKCS1v15 reports the
+	// call, the FIPS rule reports the statement)
+	_, _ = rsa.EncryptPKCS1v15(rand.Reader, pub, msg) // want: "FIPS 140-3: RSA PKCS#1 v1.5 encryption is disallowed"
+
+	// Should NOT trigger: AES and OAEP
+	_, _ = aes.NewCipher(key)
+	_, _ = rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil)
+}
+
+// --- FIPSApprovedKeys ---
+
+func checkFIPSKeys(msg []byte) {
+	// Should trigger: RSA below 2048 bits (WeakRSAKeySize reports the call,
+	// the FIPS rule reports the statement)
+	_, _ = rsa.GenerateKey(rand.Reader, 1024) // want: "FIPS 140-3: RSA key size 1024 is below the 2048-bit minimum"
+
+	// Should trigger: X25519 key agreement
+	_, _ = ecdh.X25519().GenerateKey(rand.Reader) // want: "FIPS 140-3: X25519 is not an approved key agreement scheme"
+
+	// Should NOT trigger: approved sizes and curves
+	_, _ = rsa.GenerateKey(rand.Reader, 3072)
+	_, _ = ecdh.P256().GenerateKey(rand.Reader)
+
+	// Should NOT trigger: Ed25519 signatures are approved under FIPS 186-5
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	_ = ed25519.Sign(priv, msg)
+}
+
+// --- FIPSValidatedModule ---
+
+func checkFIPSValidatedModule(key, nonce, pw, salt, priv, peer, secret []byte) {
+	// Should trigger: x/crypto AEAD and stream cipher
+	_, _ = chacha20poly1305.New(key)                     // want: "FIPS 140-3: ChaCha20-Poly1305 is not an approved AEAD"
+	_, _ = chacha20poly1305.NewX(key)                    // want: "FIPS 140-3: ChaCha20-Poly1305 is not an approved AEAD"
+	_, _ = chacha20.NewUnauthenticatedCipher(key, nonce) // want: "FIPS 140-3: ChaCha20 is not an approved cipher"
+
+	// Should trigger: non-approved password KDFs
+	_, _ = bcrypt.GenerateFromPassword(pw, bcrypt.DefaultCost) // want: "FIPS 140-3: bcrypt is not an approved password-based KDF"
+	_, _ = scrypt.Key(pw, salt, 32768, 8, 1, 32)               // want: "FIPS 140-3: scrypt is not an approved password-based KDF"
+	_ = argon2.IDKey(pw, salt, 1, 64*1024, 4, 32)              // want: "FIPS 140-3: Argon2 is not an approved password-based KDF"
+
+	// Should trigger: Curve25519 and BLAKE2
+	_, _ = curve25519.X25519(priv, peer) // want: "FIPS 140-3: Curve25519 is not an approved key agreement scheme"
+	_ = blake2b.Sum256(secret)           // want: "FIPS 140-3: BLAKE2 is not an approved hash function"
+	_ = blake2s.Sum256(secret)           // want: "FIPS 140-3: BLAKE2 is not an approved hash function"
+
+	// Should trigger: x/crypto/hkdf instead of crypto/hkdf
+	_ = hkdf.New(sha256.New, secret, salt, nil) // want: "FIPS 140-3: golang.org/x/crypto/hkdf is outside the validated module"
+
+	// Should NOT trigger: x/crypto helpers that are not primitives
+	_ = bcrypt.CompareHashAndPassword(pw, secret)
+}
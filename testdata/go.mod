@@ -2,4 +2,9 @@ module moderngo-testdata
 
 go 1.26.0
 
-require github.com/quasilyte/go-ruleguard/dsl v0.3.23 // indirect
+require (
+	github.com/quasilyte/go-ruleguard/dsl v0.3.23 // indirect
+	golang.org/x/crypto v0.54.0
+)
+
+require golang.org/x/sys v0.47.0 // indirect
@@ -1,11 +1,20 @@
 package testdata
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/netip"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // --- DeprecatedReverseProxyDirector ---
@@ -93,14 +102,348 @@ func checkJoinHostPort(host string, port int) {
 	_ = fmt.Sprintf("%s:%d (attempt %d)", host, port, 3)
 }
 
+func checkJoinHostPortConcat(host, port string, p int, u *url.URL) {
+	// Should trigger: concatenation passed to net.Dial
+	_, _ = net.Dial("tcp", host+":"+port) // want: "use net.JoinHostPort(host, port)"
+
+	// Should trigger: strconv.Itoa port passed to net.Listen
+	_, _ = net.Listen("tcp", host+":"+strconv.Itoa(p)) // want: "use net.JoinHostPort(host, strconv.Itoa(p))"
+
+	// Should trigger: http.Server.Addr
+	_ = &http.Server{Addr: host + ":" + port, ReadHeaderTimeout: time.Second} // want: "use net.JoinHostPort(host, port)"
+
+	// Should trigger both JoinHostPort and HTTPServerTimeouts
+	_ = &http.Server{ // want: "set ReadHeaderTimeout"
+		Addr: host + ":" + port, // want: "use net.JoinHostPort(host, port)"
+	}
+
+	// Should trigger: url.URL.Host field
+	_ = url.URL{Scheme: "http", Host: host + ":" + port} // want: "use net.JoinHostPort(host, port)"
+
+	// Should trigger: url.URL.Host assignment
+	u.Host = host + ":" + port // want: "use net.JoinHostPort(host, port)"
+
+	// Should trigger: variable used by the next statement
+	addr := host + ":" + port // want: "use addr := net.JoinHostPort(host, port)"
+	conn, err := net.Dial("tcp", addr)
+	_, _ = conn, err
+
+	// Should NOT trigger: cache key, not a network address
+	key := host + ":" + port
+	_ = key
+}
+
+// --- SplitHostPort (JoinHostPort) ---
+
+func checkSplitHostPort(r *http.Request, conn net.Conn, u *url.URL) {
+	// Should trigger: Split on the request host
+	host := strings.Split(r.Host, ":")[0] // want: "use host, port, err := net.SplitHostPort(r.Host)"
+	_ = host
+
+	// Should trigger: LastIndex on the remote address
+	i := strings.LastIndex(r.RemoteAddr, ":") // want: "net.SplitHostPort(r.RemoteAddr)"
+	_ = i
+
+	// Should trigger: slicing a URL host at the last colon
+	_ = u.Host[:strings.LastIndex(u.Host, ":")] // want: "net.SplitHostPort(u.Host)"
+
+	// Should trigger: connection address
+	_ = strings.Split(conn.RemoteAddr().String(), ":")[0] // want: "net.SplitHostPort(conn.RemoteAddr().String())"
+
+	// Should NOT trigger: not an address
+	line := "key:value"
+	_ = strings.Split(line, ":")[0]
+
+	// Should NOT trigger: request field that is not an address
+	_ = strings.Split(r.Method, ":")[0]
+
+	// NOT DETECTED: address copied to a local first
+	addr := r.RemoteAddr
+	_ = addr[:strings.LastIndex(addr, ":")]
+}
+
+// --- ServeMuxMethodPattern ---
+
+const ordersPath = "/orders/"
+
+func checkServeMuxMethodPattern(mux *http.ServeMux, create, list http.HandlerFunc) {
+	// Should trigger: method guard with http.MethodPost
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) { // want: "POST /users"
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		create(w, r)
+	})
+
+	// Should trigger: string literal method on the default mux
+	http.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) { // want: "GET /items"
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		list(w, r)
+	})
+
+	// Should trigger: non-literal path is concatenated
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) { // want: "+ordersPath, ...)"
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		create(w, r)
+	})
+
+	// Should trigger: any http.Method constant is upper-cased
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { // want: "OPTIONS /health"
+		if r.Method != http.MethodOptions {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		list(w, r)
+	})
+
+	// Should NOT trigger: method pattern already used
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		create(w, r)
+	})
+}
+
+// --- ServeMuxPathValue ---
+
+func checkServeMuxPathValue(w http.ResponseWriter, r *http.Request) {
+	// Should trigger: ID cut out of the path
+	id := strings.TrimPrefix(r.URL.Path, "/users/") // want: "/users/{id}"
+	_ = id
+
+	// Should NOT trigger: wildcard already used
+	name := r.PathValue("name")
+	_ = name
+
+	// Should NOT trigger: not the request path
+	rest := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	_ = rest
+}
+
+// --- NetipAddr ---
+
+func checkNetipAddr(ip1, ip2 net.IP, seen map[string]bool) bool {
+	// Should trigger: String() comparison
+	if ip1.String() == ip2.String() { // want: "comparing net.IP via String()"
+		return true
+	}
+
+	// Should trigger: bytes.Equal on net.IP
+	if bytes.Equal(ip1, ip2) { // want: "bytes.Equal on net.IP"
+		return true
+	}
+
+	// Should trigger: string map key
+	seen[ip1.String()] = true // want: "netip.Addr is comparable"
+
+	// Should trigger: ParseCIDR and IPNet.Contains
+	_, network, err := net.ParseCIDR("10.0.0.0/8") // want: "netip.ParsePrefix"
+	if err != nil {
+		return false
+	}
+	if network.Contains(ip1) { // want: "use netip.Prefix.Contains"
+		return true
+	}
+
+	// Should NOT trigger: Equal handles both forms
+	return ip1.Equal(ip2)
+}
+
+func checkNetipAddrNegative(a, b netip.Addr, seen map[netip.Addr]bool) bool {
+	// Should NOT trigger: ParseCIDR for the mask, not for Contains
+	_, network, err := net.ParseCIDR("10.0.0.0/8")
+	if err == nil {
+		ones, _ := network.Mask.Size()
+		_ = ones
+	}
+
+	// Should NOT trigger: netip already used
+	seen[a] = true
+	return a == b
+}
+
+// --- NetParseIPValidity ---
+
+func checkNetParseIPValidity(s string) bool {
+	// Should trigger: ParseIP nil check
+	if net.ParseIP(s) == nil { // want: "use netip.ParseAddr(s)"
+		return false
+	}
+
+	// Should NOT trigger: result is used
+	ip := net.ParseIP(s)
+	return ip.IsLoopback()
+}
+
+// --- HTTPServerTimeouts ---
+
+func checkHTTPServerTimeouts(h http.Handler) {
+	// Should trigger: package-level ListenAndServe
+	_ = http.ListenAndServe(":8080", h) // want: "http.ListenAndServe has no timeouts"
+
+	// Should trigger: package-level ListenAndServeTLS
+	_ = http.ListenAndServeTLS(":8443", "cert.pem", "key.pem", h) // want: "http.ListenAndServeTLS has no timeouts"
+
+	// Should trigger: Server without read timeouts
+	_ = &http.Server{Addr: ":8080", Handler: h} // want: "set ReadHeaderTimeout"
+
+	// Should NOT trigger: ReadHeaderTimeout set
+	_ = &http.Server{Addr: ":8080", Handler: h, ReadHeaderTimeout: 10 * time.Second}
+
+	// Should NOT trigger: ReadTimeout set
+	_ = &http.Server{Addr: ":8080", Handler: h, ReadTimeout: 30 * time.Second}
+}
+
+// --- HTTPClientTimeouts ---
+
+func checkHTTPClientTimeouts(url string, req *http.Request) {
+	// Should trigger: package-level Get
+	_, _ = http.Get(url) // want: "package-level http helpers use http.DefaultClient"
+
+	// Should trigger: DefaultClient
+	_, _ = http.DefaultClient.Do(req) // want: "http.DefaultClient has no timeout"
+
+	// Should trigger: client without Timeout
+	_ = &http.Client{} // want: "http.Client without Timeout"
+
+	// Should NOT trigger: client with Timeout
+	client := &http.Client{Timeout: 30 * time.Second}
+	_, _ = client.Get(url)
+}
+
+// --- CrossOriginProtection ---
+
+func checkCrossOriginProtection(w http.ResponseWriter, r *http.Request, allowed map[string]bool) bool {
+	// Should trigger: Origin compared with the host
+	origin := r.Header.Get("Origin") // want: "hand-rolled cross-origin check"
+	if origin != "" && origin != "https://"+r.Host {
+		http.Error(w, "cross-origin request", http.StatusForbidden)
+		return false
+	}
+
+	// Should trigger: Referer prefix check
+	if !strings.HasPrefix(r.Header.Get("Referer"), "https://example.com/") { // want: "hand-rolled cross-origin check"
+		return false
+	}
+
+	// Should trigger: Sec-Fetch-Site compared inline
+	if r.Header.Get("Sec-Fetch-Site") != "same-origin" { // want: "hand-rolled cross-origin check"
+		return false
+	}
+
+	// Should trigger: allowlist lookup
+	return allowed[r.Header.Get("Origin")] // want: "use AddTrustedOrigin"
+}
+
+func checkCrossOriginProtectionNegative(r *http.Request) bool {
+	// Should NOT trigger: unrelated header
+	if r.Header.Get("Content-Type") != "application/json" {
+		return false
+	}
+
+	// Should NOT trigger: Origin only logged
+	origin := r.Header.Get("Origin")
+	fmt.Println(origin)
+	return true
+}
+
+func checkCrossOriginProtectionPresence(r *http.Request) bool {
+	// Should NOT trigger: presence checks against ""
+	if r.Header.Get("Referer") == "" {
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	return r.Header.Get("Sec-Fetch-Site") != ""
+}
+
+func checkCrossOriginProtectionCORS(w http.ResponseWriter, r *http.Request, allowed map[string]bool) {
+	// Should NOT trigger: CORS echoing an allowed Origin
+	origin := r.Header.Get("Origin")
+	if allowed[origin] {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if allowed[r.Header.Get("Origin")] {
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	} else {
+		w.Header().Del("Access-Control-Allow-Origin")
+	}
+}
+
 // --- ErrorBeforeUse ---
 
-func checkErrorBeforeUse() {
-	// NOTE: ErrorBeforeUse doesn't fire — ruleguard multi-statement matching limitation
-	f, err := os.Open("test.txt")
+type client struct{ addr string }
+
+func dialClient(addr string) (*client, error) { return &client{addr: addr}, nil }
+
+func openStream(name string) (io.ReadCloser, error) { return os.Open(name) }
+
+func checkErrorBeforeUse(hc *http.Client, target string) {
+	// Should trigger: method call before the error check
+	f, err := os.Open("test.txt") // want: "f may be nil if err != nil"
 	_ = f.Name()
 	if err != nil {
 		return
 	}
 	_ = f
+
+	// Should trigger: field read via defer before the error check
+	resp, err := hc.Get(target) // want: "resp may be nil if err != nil"
+	defer resp.Body.Close()
+	if err != nil {
+		return
+	}
+
+	// Should trigger: expression statement on a *sql.DB
+	db, err := sql.Open("postgres", "dsn") // want: "db may be nil if err != nil"
+	db.SetMaxOpenConns(10)
+	if err != nil {
+		return
+	}
+
+	// Should trigger: user function returning a pointer
+	c, err := dialClient("localhost:9000") // want: "c may be nil if err != nil"
+	fmt.Println(c.addr)
+	if err != nil {
+		return
+	}
+
+	// Should trigger: user function returning an interface
+	rc, err := openStream("test.txt") // want: "rc may be nil if err != nil"
+	defer rc.Close()
+	if err != nil {
+		return
+	}
+
+	// Should NOT trigger: []byte is neither a pointer nor an interface
+	b, err := json.Marshal(c)
+	fmt.Println(len(b))
+	if err != nil {
+		return
+	}
+
+	// Should NOT trigger: error checked first
+	c2, err := dialClient("localhost:9001")
+	if err != nil {
+		return
+	}
+	fmt.Println(c2.addr)
+
+	// Should NOT trigger: err reassigned in between
+	c3, err := dialClient("localhost:9002")
+	if err != nil {
+		return
+	}
+	err = os.Remove(c3.addr)
+	if err != nil {
+		return
+	}
 }